@@ -4,73 +4,63 @@ import (
 	"context"
 	"fmt"
 	"strings"
-
-	openai "github.com/sashabaranov/go-openai"
-	"wapuugotchi/feed/app/env"
+	"sync"
 )
 
-const (
-	githubEndpoint = "https://models.inference.ai.azure.com"
-	githubModel    = "gpt-4o-mini"
+var (
+	backendMu sync.Mutex
+	backend   Backend
 )
 
-// TransformText nimmt ein Prompt-Pattern und Text, baut den finalen Prompt und ruft GitHub Models auf.
-func TransformText(pattern, text string) (string, error) {
-	prompt := buildPrompt(pattern, text)
-	return transformWithGitHub(prompt)
+// TransformText nimmt ein Prompt-Pattern und Text, baut den finalen Prompt und ruft das konfigurierte Backend auf.
+func TransformText(ctx context.Context, pattern, text string) (string, error) {
+	return Complete(ctx, buildPrompt(pattern, text))
 }
 
-func buildPrompt(pattern, text string) string {
-	pattern = strings.TrimSpace(pattern)
-	if pattern == "" {
-		return text
-	}
-	if strings.Contains(pattern, "%s") {
-		return fmt.Sprintf(pattern, text)
+// Complete schickt einen fertig gerenderten Prompt an das konfigurierte Backend (mit Cache); ctx bricht den Call ab.
+func Complete(ctx context.Context, prompt string) (string, error) {
+	if err := ctx.Err(); err != nil { // Schon abgebrochen: gar nicht erst anfragen.
+		return "", err
 	}
-	return pattern + text
-}
-
-func transformWithGitHub(prompt string) (string, error) {
-	token, err := loadGitHubToken()
+	current, err := currentBackend()
 	if err != nil {
 		return "", err
 	}
+	return completeCached(ctx, current, prompt)
+}
 
-	cfg := openai.DefaultConfig(token)
-	cfg.BaseURL = githubEndpoint
-	client := openai.NewClientWithConfig(cfg)
+// SetBackend ersetzt das Backend aus der Umgebung (z.B. durch den Stub); nil setzt es zurück.
+func SetBackend(b Backend) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	backend = b
+}
 
-	resp, err := client.CreateChatCompletion(context.Background(),
-		openai.ChatCompletionRequest{
-			Model: githubModel,
-			Messages: []openai.ChatCompletionMessage{
-				{Role: openai.ChatMessageRoleUser, Content: prompt},
-			},
-		},
-	)
-	if err != nil {
-		return "", fmt.Errorf("github models api: %w", err)
+func currentBackend() (Backend, error) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	if backend != nil {
+		return backend, nil
 	}
-	if len(resp.Choices) == 0 {
-		return "", fmt.Errorf("github models api returned no choices")
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
 	}
-	result := strings.TrimSpace(resp.Choices[0].Message.Content)
-	if result == "" {
-		return "", fmt.Errorf("github models api returned empty response")
+	created, err := NewBackend(cfg)
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
+	backend = created
+	return backend, nil
 }
 
-func loadGitHubToken() (string, error) {
-	if token := env.ReadEnv("GH_MODELS_TOKEN"); token != "" {
-		return token, nil
-	}
-	if err := env.LoadDotEnv(); err != nil {
-		return "", err
+func buildPrompt(pattern, text string) string {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return text
 	}
-	if token := env.ReadEnv("GH_MODELS_TOKEN"); token != "" {
-		return token, nil
+	if strings.Contains(pattern, "%s") {
+		return fmt.Sprintf(pattern, text)
 	}
-	return "", fmt.Errorf("missing GitHub token: set GITHUB_TOKEN or GH_MODELS_TOKEN")
+	return pattern + text
 }
@@ -0,0 +1,77 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTransformTextUsesBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string // Feste Stub-Antwort; leer => Stub gibt den Eingabetext zurück.
+		pattern  string
+		text     string
+		want     string
+	}{
+		{name: "pattern with placeholder", pattern: "Summarize this.\n\nText:\n\n%s", text: "WordPress 6.8 is out.", want: "WordPress 6.8 is out."},
+		{name: "pattern without placeholder", pattern: "Summarize:", text: "hello", want: "Summarize:hello"},
+		{name: "empty pattern", pattern: "  ", text: "just text", want: "just text"},
+		{name: "fixed response", response: "<p>done</p>", pattern: "Text:\n\n%s", text: "ignored", want: "<p>done</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetBackend(NewStubBackend(tt.response))
+			t.Cleanup(func() { SetBackend(nil) })
+
+			got, err := TransformText(context.Background(), tt.pattern, tt.text)
+			if err != nil {
+				t.Fatalf("TransformText: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TransformText = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformTextStubRejectsEmptyPrompt(t *testing.T) {
+	SetBackend(NewStubBackend(""))
+	t.Cleanup(func() { SetBackend(nil) })
+
+	if _, err := TransformText(context.Background(), "", " "); err == nil {
+		t.Fatal("TransformText with empty prompt: want error, got nil")
+	}
+}
+
+func TestCompleteCanceled(t *testing.T) {
+	SetBackend(NewStubBackend("unused"))
+	t.Cleanup(func() { SetBackend(nil) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Complete(ctx, "prompt"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Complete after cancel = %v, want context.Canceled", err)
+	}
+}
+
+func TestNewBackend(t *testing.T) {
+	t.Setenv("AI_STUB_RESPONSE", "fixed")
+	b, err := NewBackend(Config{Backend: BackendStub})
+	if err != nil {
+		t.Fatalf("NewBackend(stub): %v", err)
+	}
+	if b.Name() != BackendStub {
+		t.Errorf("Name = %q, want %q", b.Name(), BackendStub)
+	}
+	if got, _ := b.Complete(context.Background(), "anything"); got != "fixed" {
+		t.Errorf("Complete = %q, want AI_STUB_RESPONSE", got)
+	}
+
+	if _, err := NewBackend(Config{Backend: BackendOpenAI}); err == nil {
+		t.Error("NewBackend(openai) without AI_BASE_URL: want error")
+	}
+	if _, err := NewBackend(Config{Backend: "bogus"}); err == nil {
+		t.Error("NewBackend(bogus): want error")
+	}
+}
@@ -0,0 +1,90 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"wapuugotchi/feed/app/env"
+)
+
+const (
+	BackendGitHub = "github"
+	BackendOpenAI = "openai"
+	BackendStub   = "stub"
+
+	githubEndpoint = "https://models.inference.ai.azure.com"
+	defaultModel   = "gpt-4o-mini"
+)
+
+// Backend ist ein LLM-Anbieter, der einen fertigen Prompt in eine Antwort übersetzt.
+type Backend interface {
+	Name() string
+	Model() string
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// Config wählt Backend, Modell und Endpoint (AI_BACKEND, AI_MODEL, AI_BASE_URL, AI_API_KEY).
+type Config struct {
+	Backend string
+	Model   string
+	BaseURL string
+	APIKey  string
+}
+
+// ConfigFromEnv liest die Backend-Konfiguration aus der Umgebung bzw. der .env Datei.
+func ConfigFromEnv() (Config, error) {
+	if err := env.LoadDotEnv(); err != nil {
+		return Config{}, err
+	}
+	return Config{
+		Backend: strings.ToLower(env.ReadEnv("AI_BACKEND")),
+		Model:   env.ReadEnv("AI_MODEL"),
+		BaseURL: env.ReadEnv("AI_BASE_URL"),
+		APIKey:  env.ReadEnv("AI_API_KEY"),
+	}, nil
+}
+
+// NewBackend erzeugt das Backend zur Konfiguration; ohne AI_BACKEND wird GitHub Models genutzt.
+func NewBackend(cfg Config) (Backend, error) {
+	switch cfg.Backend {
+	case "", BackendGitHub:
+		token, err := loadGitHubToken()
+		if err != nil {
+			return nil, err
+		}
+		baseURL := cfg.BaseURL
+		if baseURL == "" {
+			baseURL = githubEndpoint
+		}
+		return newOpenAIBackend("github models", BackendGitHub, baseURL, token, modelOrDefault(cfg.Model)), nil
+	case BackendOpenAI:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("ai backend %q needs AI_BASE_URL", cfg.Backend)
+		}
+		return newOpenAIBackend("openai-compatible", BackendOpenAI, cfg.BaseURL, cfg.APIKey, modelOrDefault(cfg.Model)), nil
+	case BackendStub:
+		return NewStubBackend(env.ReadEnv("AI_STUB_RESPONSE")), nil
+	}
+	return nil, fmt.Errorf("unknown ai backend %q (use %q, %q or %q)", cfg.Backend, BackendGitHub, BackendOpenAI, BackendStub)
+}
+
+func modelOrDefault(model string) string {
+	if model == "" {
+		return defaultModel
+	}
+	return model
+}
+
+func loadGitHubToken() (string, error) {
+	if token := env.ReadEnv("GH_MODELS_TOKEN"); token != "" {
+		return token, nil
+	}
+	if err := env.LoadDotEnv(); err != nil {
+		return "", err
+	}
+	if token := env.ReadEnv("GH_MODELS_TOKEN"); token != "" {
+		return token, nil
+	}
+	return "", fmt.Errorf("missing GitHub token: set GITHUB_TOKEN or GH_MODELS_TOKEN")
+}
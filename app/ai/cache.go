@@ -0,0 +1,129 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CacheOptions steuert den persistenten Antwort-Cache (ein JSON-File pro Prompt-Hash).
+type CacheOptions struct {
+	Dir     string        // Verzeichnis, z.B. data/ai-cache; leer => kein Cache.
+	TTL     time.Duration // Maximales Alter eines Eintrags; 0 => unbegrenzt.
+	Refresh bool          // Vorhandene Einträge ignorieren und neu anfragen (--refresh-ai).
+}
+
+type cacheEntry struct {
+	Backend   string `json:"backend"`
+	BaseURL   string `json:"base_url,omitempty"`
+	Model     string `json:"model"`
+	Response  string `json:"response"`
+	CreatedAt string `json:"created_at"`
+}
+
+var (
+	cacheMu   sync.Mutex
+	cacheOpts CacheOptions
+	writeMu   sync.Mutex // Parallele Provider können denselben Prompt schreiben (gemeinsame .tmp-Datei).
+)
+
+// ConfigureCache aktiviert (oder mit leerem Dir deaktiviert) den Antwort-Cache.
+func ConfigureCache(opts CacheOptions) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cacheOpts = opts
+}
+
+func cacheConfig() CacheOptions {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	return cacheOpts
+}
+
+// completeCached fragt zuerst den Cache und nur bei Miss das Backend; Antworten werden danach gespeichert.
+func completeCached(ctx context.Context, b Backend, prompt string) (string, error) {
+	opts := cacheConfig()
+	if opts.Dir == "" {
+		return b.Complete(ctx, prompt)
+	}
+
+	path := filepath.Join(opts.Dir, cacheKey(b, prompt)+".json")
+	if !opts.Refresh {
+		if response, ok := readCache(path, opts.TTL); ok {
+			return response, nil
+		}
+	}
+
+	response, err := b.Complete(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	// Schreibfehler sind nicht fatal: die Antwort ist trotzdem gültig, nur beim nächsten Lauf nicht gecacht.
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	_ = writeCache(path, cacheEntry{
+		Backend:   b.Name(),
+		BaseURL:   baseURL(b),
+		Model:     b.Model(),
+		Response:  response,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	return response, nil
+}
+
+// cacheKey trennt Antworten nach Backend, Endpoint und Modell: zwei OpenAI-kompatible Server mit
+// gleichem Modellnamen (z.B. lokales Ollama und ein gehosteter Dienst) teilen sich keine Einträge.
+func cacheKey(b Backend, prompt string) string {
+	sum := sha256.Sum256([]byte(b.Name() + "\x00" + baseURL(b) + "\x00" + b.Model() + "\x00" + prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+func baseURL(b Backend) string { // Endpoint des Backends; leer, wenn es keinen hat (Stub).
+	if endpoint, ok := b.(interface{ BaseURL() string }); ok {
+		return endpoint.BaseURL()
+	}
+	return ""
+}
+
+func readCache(path string, ttl time.Duration) (string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Response == "" {
+		return "", false
+	}
+	if ttl > 0 {
+		createdAt, err := time.Parse(time.RFC3339, entry.CreatedAt)
+		if err != nil || time.Since(createdAt) > ttl {
+			return "", false
+		}
+	}
+	return entry.Response, true
+}
+
+func writeCache(path string, entry cacheEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false) // HTML-Antworten lesbar halten (kein \u003c).
+	if err := enc.Encode(entry); err != nil {
+		return err
+	}
+	// Erst in eine Temp-Datei schreiben, dann umbenennen: kein halbes JSON bei Abbruch.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,64 @@
+package ai
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCacheKey(t *testing.T) {
+	ollama := newOpenAIBackend("openai-compatible", BackendOpenAI, "http://localhost:11434/v1/", "", "llama3")
+	hosted := newOpenAIBackend("openai-compatible", BackendOpenAI, "https://api.example.org/v1", "", "llama3")
+	same := newOpenAIBackend("openai-compatible", BackendOpenAI, "http://localhost:11434/v1", "", "llama3")
+	other := newOpenAIBackend("openai-compatible", BackendOpenAI, "http://localhost:11434/v1", "", "mistral")
+
+	if cacheKey(ollama, "p") == cacheKey(hosted, "p") {
+		t.Error("same model on different base urls shares a cache key")
+	}
+	if cacheKey(ollama, "p") != cacheKey(same, "p") { // Slash am Ende ist egal.
+		t.Error("identical endpoint yields different cache keys")
+	}
+	if cacheKey(ollama, "p") == cacheKey(other, "p") {
+		t.Error("different models share a cache key")
+	}
+	if cacheKey(ollama, "p") == cacheKey(ollama, "q") {
+		t.Error("different prompts share a cache key")
+	}
+}
+
+func TestCompleteCachedSeparatesEndpoints(t *testing.T) {
+	ConfigureCache(CacheOptions{Dir: t.TempDir()})
+	t.Cleanup(func() { ConfigureCache(CacheOptions{}) })
+
+	first := &fixedBackend{baseURL: "http://localhost:11434/v1", response: "local"}
+	second := &fixedBackend{baseURL: "https://api.example.org/v1", response: "hosted"}
+	for _, tt := range []struct {
+		backend *fixedBackend
+		want    string
+	}{
+		{backend: first, want: "local"},
+		{backend: second, want: "hosted"}, // Kein Treffer aus dem Cache des anderen Endpoints.
+		{backend: first, want: "local"},
+	} {
+		got, err := completeCached(context.Background(), tt.backend, "prompt")
+		if err != nil || got != tt.want {
+			t.Errorf("completeCached(%s) = %q, %v; want %q", tt.backend.baseURL, got, err, tt.want)
+		}
+	}
+	if first.calls != 1 || second.calls != 1 {
+		t.Errorf("backend calls = %d, %d; want 1, 1 (second call for first served from cache)", first.calls, second.calls)
+	}
+}
+
+type fixedBackend struct {
+	baseURL  string
+	response string
+	calls    int
+}
+
+func (b *fixedBackend) Name() string    { return BackendOpenAI }
+func (b *fixedBackend) Model() string   { return "llama3" }
+func (b *fixedBackend) BaseURL() string { return b.baseURL }
+func (b *fixedBackend) Complete(context.Context, string) (string, error) {
+	b.calls++
+	return b.response, nil
+}
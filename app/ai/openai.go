@@ -0,0 +1,58 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// openAIBackend spricht jede OpenAI-kompatible Chat-API an (GitHub Models, Ollama, llama.cpp, …).
+type openAIBackend struct {
+	label   string
+	name    string
+	model   string
+	baseURL string
+	client  *openai.Client
+}
+
+func newOpenAIBackend(label, name, baseURL, token, model string) *openAIBackend {
+	cfg := openai.DefaultConfig(token)
+	cfg.BaseURL = strings.TrimRight(baseURL, "/")
+	return &openAIBackend{
+		label:   label,
+		name:    name,
+		model:   model,
+		baseURL: cfg.BaseURL,
+		client:  openai.NewClientWithConfig(cfg),
+	}
+}
+
+func (b *openAIBackend) Name() string  { return b.name }
+func (b *openAIBackend) Model() string { return b.model }
+
+// BaseURL gehört zum Cache-Schlüssel: gleiches Modell auf einem anderen Server kann anders antworten.
+func (b *openAIBackend) BaseURL() string { return b.baseURL }
+
+func (b *openAIBackend) Complete(ctx context.Context, prompt string) (string, error) {
+	resp, err := b.client.CreateChatCompletion(ctx,
+		openai.ChatCompletionRequest{
+			Model: b.model,
+			Messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: prompt},
+			},
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("%s api: %w", b.label, err)
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("%s api returned no choices", b.label)
+	}
+	result := strings.TrimSpace(resp.Choices[0].Message.Content)
+	if result == "" {
+		return "", fmt.Errorf("%s api returned empty response", b.label)
+	}
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// stubBackend antwortet deterministisch und ohne Netzwerk (Tests, Offline-Läufe).
+type stubBackend struct {
+	response string
+}
+
+// NewStubBackend liefert immer response; ist response leer, wird der Text-Teil des Prompts zurückgegeben.
+func NewStubBackend(response string) Backend {
+	return &stubBackend{response: strings.TrimSpace(response)}
+}
+
+func (b *stubBackend) Name() string  { return BackendStub }
+func (b *stubBackend) Model() string { return BackendStub }
+
+func (b *stubBackend) Complete(_ context.Context, prompt string) (string, error) {
+	if b.response != "" {
+		return b.response, nil
+	}
+	// Die Patterns enden mit "…Text:\n\n%s": der letzte Block nach einer Leerzeile ist der Eingabetext.
+	text := strings.TrimSpace(prompt)
+	if index := strings.LastIndex(text, "\n\n"); index >= 0 {
+		text = strings.TrimSpace(text[index+2:])
+	}
+	if text == "" {
+		return "", fmt.Errorf("stub backend: empty prompt")
+	}
+	return text, nil
+}
@@ -1,10 +1,30 @@
 package cmd // Paket "cmd": enthält CLI-nahe Logik und Wrapper-Funktionen für Kommandozeilenbefehle.
 
-import "wapuugotchi/feed/app/ai" // Importiert das AI-Paket, das die eigentliche Text-Transformation ausführt.
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"context" // Abbruch des KI-Calls.
+	"fmt"     // Antwort auf Stdout.
+	"strings" // Leeres Pattern erkennen.
+
+	"wapuugotchi/feed/app/ai" // Importiert das AI-Paket, das die eigentliche Text-Transformation ausführt.
+)
 
 const defaultPattern = "Text:\n\n%s" // Default-Prompt für die CLI; %s wird durch den übergebenen Text ersetzt.
 
 // TransformTextByAi uses the default prompt for the CLI. // Dokumentationskommentar: beschreibt Zweck der Funktion.
-func TransformTextByAi(text string) (string, error) { // Öffentliche Hilfsfunktion: kapselt KI-Aufruf für CLI-Nutzung.
-	return ai.TransformText(defaultPattern, text) // Ruft die zentrale KI-Funktion mit Default-Prompt + Text auf und gibt Ergebnis/Fehler direkt zurück.
+func TransformTextByAi(ctx context.Context, text string) (string, error) { // Öffentliche Hilfsfunktion: kapselt KI-Aufruf für CLI-Nutzung.
+	return ai.TransformText(ctx, defaultPattern, text) // Ruft die zentrale KI-Funktion mit Default-Prompt + Text auf und gibt Ergebnis/Fehler direkt zurück.
+}
+
+// RunAI schickt text durch das konfigurierte KI-Backend und gibt die Antwort aus; ohne pattern gilt defaultPattern.
+func RunAI(ctx context.Context, pattern, text string) error {
+	transform := func(ctx context.Context, text string) (string, error) { return ai.TransformText(ctx, pattern, text) }
+	if strings.TrimSpace(pattern) == "" {
+		transform = TransformTextByAi
+	}
+	result, err := transform(ctx, text)
+	if err != nil {
+		return err
+	}
+	fmt.Println(result)
+	return nil
 }
@@ -0,0 +1,217 @@
+package cmd // Paket "cmd": Artikel unter articles/ anlegen und prüfen.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"bytes"         // Artikel-JSON streng parsen.
+	"crypto/rand"   // Zufällige Artikel-IDs.
+	"encoding/hex"  // ID als Hex-String.
+	"encoding/json" // Vorlage von Stdin/Datei, strenges Parsen.
+	"errors"        // Existierende Datei erkennen.
+	"fmt"           // Fehlertexte, Meldung mit Pfad.
+	"io"            // Vorlage/Inhalt von Stdin.
+	"io/fs"         // fs.ErrNotExist.
+	"net/url"       // Links prüfen.
+	"os"            // Verzeichnis anlegen, Datei prüfen.
+	"path/filepath" // Pfad im articles-Verzeichnis.
+	"strings"       // Felder trimmen.
+	"time"          // Default für created_at.
+
+	"wapuugotchi/feed/app/feed" // feed.MarkdownToHTML für die Prüfung von .md-Artikeln.
+)
+
+type ArticleOptions struct { // Felder eines neuen Artikels (aus CLI-Flags); leere Felder übernehmen die Vorlage.
+	From       string   // Vorlage als Artikel-JSON (Datei oder "-" für Stdin); leer => keine.
+	Title      string   // Pflicht (hier oder in der Vorlage).
+	Link       string   // Link zum Original (optional).
+	Content    string   // Inhalt als HTML; "-" => von Stdin.
+	Iframe     string   // Optionales Embed.
+	CreatedAt  string   // RFC3339; leer => jetzt.
+	Categories []string // Kategorien; leere Werte werden entfernt.
+	Markdown   bool     // Als .md mit Front Matter anlegen; Content ist dann Markdown.
+}
+
+type articleProblem struct { // Ein Problem in einer Artikel-Datei: Datei, Feld und Grund.
+	file   string
+	field  string // Leer, wenn die ganze Datei betroffen ist (z.B. kaputtes JSON).
+	reason string
+}
+
+func (p articleProblem) Error() string {
+	if p.field == "" {
+		return fmt.Sprintf("%s: %s", p.file, p.reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.file, p.field, p.reason)
+}
+
+// RunAddArticle legt articles/<datum>-<slug>-<id>.json (bzw. .md) mit neuer ID an; bestehende Dateien werden nicht überschrieben.
+func RunAddArticle(root string, opts ArticleOptions, stdin io.Reader) error {
+	paths, err := getPaths(root)
+	if err != nil {
+		return err
+	}
+	entry, err := articleFromOptions(opts, stdin)
+	if err != nil {
+		return err
+	}
+	entry.ID, err = newArticleID()
+	if err != nil {
+		return err
+	}
+
+	ext := ".json"
+	if opts.Markdown {
+		ext = ".md"
+	}
+	path := filepath.Join(paths.articles, articleFileName(entry, ext))
+	rendered := entry
+	if opts.Markdown { // Geprüft wird, was der Feed später sieht.
+		rendered.Content = feed.MarkdownToHTML(entry.Content)
+	}
+	if problems := checkArticle(path, rendered); len(problems) > 0 { // Nichts schreiben, was validate ablehnen würde.
+		return errors.Join(problems...)
+	}
+
+	if err := os.MkdirAll(paths.articles, 0755); err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s already exists", path)
+	}
+	if opts.Markdown {
+		err = writeMarkdownArticle(path, entry)
+	} else {
+		err = writeJSONFile(path, entry)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("article %s written to %s\n", shortID(entry.ID), path)
+	return nil
+}
+
+func articleFromOptions(opts ArticleOptions, stdin io.Reader) (Entry, error) { // Vorlage laden, Flags darüberlegen.
+	var entry Entry
+	if opts.From != "" {
+		data, err := readInput(opts.From, stdin)
+		if err != nil {
+			return Entry{}, err
+		}
+		if err := decodeArticle(data, &entry); err != nil {
+			return Entry{}, fmt.Errorf("%s: %w", opts.From, err)
+		}
+	}
+	if opts.Content == "-" {
+		if opts.From == "-" {
+			return Entry{}, fmt.Errorf("stdin can only be used once (for --from or --content)")
+		}
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return Entry{}, err
+		}
+		opts.Content = string(data)
+	}
+
+	override := func(target *string, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			*target = value
+		}
+	}
+	override(&entry.Title, opts.Title)
+	override(&entry.Link, opts.Link)
+	override(&entry.Content, opts.Content)
+	override(&entry.Iframe, opts.Iframe)
+	override(&entry.CreatedAt, opts.CreatedAt)
+	if categories := cleanCategories(opts.Categories); len(categories) > 0 {
+		entry.Categories = categories
+	}
+	entry.Title = strings.TrimSpace(entry.Title)
+	entry.Link = strings.TrimSpace(entry.Link)
+	entry.Content = strings.TrimSpace(entry.Content)
+	entry.Iframe = strings.TrimSpace(entry.Iframe)
+	entry.CreatedAt = strings.TrimSpace(entry.CreatedAt)
+	if entry.CreatedAt == "" {
+		entry.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	entry.Source = "" // Wird beim Laden gesetzt, gehört nicht in die Datei.
+	entry.Release = nil
+	return entry, nil
+}
+
+func readInput(path string, stdin io.Reader) ([]byte, error) { // Datei oder "-" für Stdin.
+	if path == "-" {
+		return io.ReadAll(stdin)
+	}
+	return os.ReadFile(path)
+}
+
+func decodeArticle(data []byte, entry *Entry) error { // Wie json.Unmarshal, aber Tippfehler in Feldnamen fallen auf.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(entry)
+}
+
+func articleFileName(entry Entry, ext string) string { // <datum>-<slug>-<id>.<ext>, gleiches Schema wie die vorhandenen Artikel.
+	slug := slugify(entry.Title, "article")
+	created, err := parseTime(entry.CreatedAt)
+	if err != nil { // Ungültiges Datum lehnt checkArticle ohnehin ab.
+		return slug + ext
+	}
+	return created.UTC().Format("20060102") + "-" + slug + "-" + shortID(entry.ID) + ext
+}
+
+func newArticleID() (string, error) { // 128 Bit Zufall als Hex, wie die vorhandenen Artikel-IDs.
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id[:]), nil
+}
+
+func checkArticle(file string, entry Entry) []error { // Alle Feldprobleme eines Artikels (ohne Dateiebene).
+	var problems []error
+	problem := func(field, reason string, args ...any) {
+		problems = append(problems, articleProblem{file: file, field: field, reason: fmt.Sprintf(reason, args...)})
+	}
+
+	if strings.TrimSpace(entry.Title) == "" {
+		problem("title", "missing")
+	}
+	if createdAt := strings.TrimSpace(entry.CreatedAt); createdAt == "" {
+		problem("created_at", "missing")
+	} else if _, err := parseTime(createdAt); err != nil {
+		problem("created_at", "%q is not RFC3339 (e.g. 2026-04-11T09:00:00Z)", createdAt)
+	}
+	if strings.TrimSpace(entry.Content) == "" {
+		problem("content", "missing")
+	}
+	if link := strings.TrimSpace(entry.Link); link != "" && !absoluteURL(link, "http", "https") {
+		problem("link", "%q is not an absolute http(s) url", link)
+	}
+	if iframe := strings.TrimSpace(entry.Iframe); iframe != "" && !absoluteURL(iframe, "https") {
+		problem("iframe", "%q is not an absolute https url", iframe)
+	}
+	for i, category := range entry.Categories {
+		if strings.TrimSpace(category) == "" {
+			problem(fmt.Sprintf("categories[%d]", i), "empty")
+		}
+	}
+	return problems
+}
+
+func problemFields(problems []error) []string { // Betroffene Felder für eine einzeilige Meldung.
+	var fields []string
+	for _, err := range problems {
+		var problem articleProblem
+		if errors.As(err, &problem) && problem.field != "" && !containsFold(fields, problem.field) {
+			fields = append(fields, problem.field)
+		}
+	}
+	return fields
+}
+
+func absoluteURL(value string, schemes ...string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	return containsFold(schemes, parsed.Scheme)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckArticle(t *testing.T) {
+	valid := Entry{
+		Title:      "Hello",
+		CreatedAt:  "2026-04-11T09:00:00Z",
+		Content:    "<p>Text</p>",
+		Link:       "https://example.org/hello",
+		Iframe:     "https://wordpress.tv/embed/x",
+		Categories: []string{"News"},
+	}
+	tests := []struct {
+		name   string
+		change func(*Entry)
+		fields []string // Erwartete Felder mit Problemen; leer => gültig.
+	}{
+		{name: "valid", change: func(*Entry) {}},
+		{name: "no link or iframe", change: func(e *Entry) { e.Link, e.Iframe = "", "" }},
+		{name: "missing title", change: func(e *Entry) { e.Title = "  " }, fields: []string{"title"}},
+		{name: "missing content", change: func(e *Entry) { e.Content = "\n" }, fields: []string{"content"}},
+		{name: "missing created_at", change: func(e *Entry) { e.CreatedAt = "" }, fields: []string{"created_at"}},
+		{name: "created_at without zone", change: func(e *Entry) { e.CreatedAt = "2026-04-11 09:00" }, fields: []string{"created_at"}},
+		{name: "relative link", change: func(e *Entry) { e.Link = "/hello" }, fields: []string{"link"}},
+		{name: "ftp link", change: func(e *Entry) { e.Link = "ftp://example.org/x" }, fields: []string{"link"}},
+		{name: "http iframe", change: func(e *Entry) { e.Iframe = "http://wordpress.tv/embed/x" }, fields: []string{"iframe"}},
+		{name: "empty category", change: func(e *Entry) { e.Categories = []string{"News", " "} }, fields: []string{"categories[1]"}},
+		{name: "everything missing", change: func(e *Entry) { *e = Entry{} }, fields: []string{"title", "created_at", "content"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := valid
+			tt.change(&entry)
+			if got := problemFields(checkArticle("a.json", entry)); !reflect.DeepEqual(got, tt.fields) {
+				t.Errorf("problem fields = %v, want %v", got, tt.fields)
+			}
+		})
+	}
+}
+
+func TestArticleFileName(t *testing.T) {
+	tests := []struct {
+		entry Entry
+		ext   string
+		want  string
+	}{
+		{entry: Entry{ID: "0123456789abcdef", Title: "Hello World!", CreatedAt: "2026-04-11T09:00:00Z"}, ext: ".json", want: "20260411-hello-world-01234567.json"},
+		{entry: Entry{ID: "0123456789abcdef", Title: "Spät", CreatedAt: "2026-04-11T23:30:00-02:00"}, ext: ".md", want: "20260412-sp-t-01234567.md"}, // Datum in UTC.
+		{entry: Entry{ID: "0123456789abcdef", Title: "???", CreatedAt: "2026-04-11T09:00:00Z"}, ext: ".json", want: "20260411-article-01234567.json"},
+		{entry: Entry{ID: "0123456789abcdef", Title: "No date", CreatedAt: "yesterday"}, ext: ".json", want: "no-date.json"},
+	}
+	for _, tt := range tests {
+		if got := articleFileName(tt.entry, tt.ext); got != tt.want {
+			t.Errorf("articleFileName(%q, %q) = %q, want %q", tt.entry.Title, tt.entry.CreatedAt, got, tt.want)
+		}
+	}
+}
+
+func TestLoadArticleEntriesSkipsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"ok.json":         `{"title": "Ok", "created_at": "2026-04-11T09:00:00Z", "content": "<p>x</p>"}`,
+		"no-content.json": `{"title": "Empty", "created_at": "2026-04-11T09:00:00Z"}`,
+		"bad-link.json":   `{"title": "Link", "created_at": "2026-04-11T09:00:00Z", "content": "<p>x</p>", "link": "example.org"}`,
+		"no-content.md":   "---\ntitle: Empty markdown\ncreated_at: 2026-04-11T09:00:00Z\n---\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var titles []string
+	for _, entry := range loadArticleEntries(dir) {
+		titles = append(titles, entry.Title)
+	}
+	if want := []string{"Ok"}; !reflect.DeepEqual(titles, want) { // Was validate ablehnt, landet auch nicht im Feed.
+		t.Errorf("loaded articles = %v, want %v", titles, want)
+	}
+}
@@ -0,0 +1,135 @@
+package cmd // Paket "cmd": Atom-1.0-Ausgabe (feed.atom) aus denselben Entries wie feed.xml.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/xml" // Atom-XML generieren.
+	"net/url"      // Self-Link relativ zum Site-Link auflösen.
+	"strings"      // Trimmen von Links/Iframes.
+	"time"         // RFC3339-Zeitstempel für <updated>/<published>.
+)
+
+const atomNamespace = "http://www.w3.org/2005/Atom" // Pflicht-Namespace für Atom 1.0.
+
+type AtomFeed struct { // Root-Objekt für Atom 1.0 (<feed>).
+	XMLName      xml.Name    `xml:"feed"`                   // Setzt Root-Tag <feed>.
+	Xmlns        string      `xml:"xmlns,attr"`             // Atom-Namespace als Attribut.
+	XmlnsRelease string      `xml:"xmlns:wapuugotchi,attr"` // Namespace für wapuugotchi:release.
+	ID           string      `xml:"id"`                     // Permanente Feed-ID (IRI).
+	Title        string      `xml:"title"`                  // Feed-Titel.
+	Subtitle     string      `xml:"subtitle,omitempty"`     // Feed-Beschreibung.
+	Updated      string      `xml:"updated"`                // Zeitpunkt der letzten Änderung (RFC3339).
+	Author       AtomPerson  `xml:"author"`                 // Feed-Autor; gilt für Entries ohne eigenen Autor.
+	Links        []AtomLink  `xml:"link"`                   // alternate (Website) + self (feed.atom).
+	Entries      []AtomEntry `xml:"entry"`                  // Liste der <entry> Elemente.
+}
+
+type AtomPerson struct { // <author> mit Pflichtfeld <name>.
+	Name string `xml:"name"`
+}
+
+type AtomLink struct { // <link rel="..." href="..."/>.
+	Rel    string `xml:"rel,attr,omitempty"`    // alternate, self, related, enclosure …
+	Type   string `xml:"type,attr,omitempty"`   // Optionaler MIME-Type.
+	Href   string `xml:"href,attr"`             // Ziel-URL.
+	Length int64  `xml:"length,attr,omitempty"` // Größe in Bytes (nur enclosure).
+}
+
+type AtomCategory struct { // <category term="..."/>.
+	Term string `xml:"term,attr"`
+}
+
+type AtomContent struct { // <content type="html">…</content>; HTML wird dabei escaped.
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type AtomEntry struct { // Atom Entry: einzelne Nachricht.
+	ID         string         `xml:"id"`                            // Permanente Entry-ID (IRI aus Entry.ID).
+	Title      string         `xml:"title"`                         // <title>
+	Updated    string         `xml:"updated"`                       // RFC3339; bei uns gleich dem Veröffentlichungszeitpunkt.
+	Published  string         `xml:"published"`                     // RFC3339 aus CreatedAt.
+	Author     *AtomPerson    `xml:"author,omitempty"`              // Autor aus der Quelle; sonst gilt der Feed-Autor.
+	Links      []AtomLink     `xml:"link"`                          // alternate (Original) + optional related (Iframe).
+	Categories []AtomCategory `xml:"category,omitempty"`            // <category term> mehrfach möglich.
+	Content    *AtomContent   `xml:"content,omitempty"`             // Voller HTML-Content.
+	Release    *Release       `xml:"wapuugotchi:release,omitempty"` // Release-Daten (Version, Kanal, …).
+}
+
+func writeAtom(site Site, entries []Entry, outputPath string) error { // Baut feed.atom aus Site + absteigend sortierten Entries.
+	feed := AtomFeed{
+		Xmlns:        atomNamespace,
+		XmlnsRelease: releaseNamespace,
+		ID:           atomFeedID(site),
+		Title:        site.Title,
+		Subtitle:     site.Description,
+		Updated:      time.Unix(0, 0).UTC().Format(time.RFC3339), // Platzhalter, falls es keine Entries gibt (stabil statt "jetzt").
+		Author:       AtomPerson{Name: site.Title},
+	}
+	if link := strings.TrimSpace(site.Link); link != "" {
+		feed.Links = append(feed.Links,
+			AtomLink{Rel: "alternate", Type: "text/html", Href: link},
+			AtomLink{Rel: "self", Type: "application/atom+xml", Href: resolveLink(link, atomFile)},
+		)
+	}
+
+	for _, entry := range entries {
+		createdAt, err := parseTime(entry.CreatedAt)
+		if err != nil { // Wie bei RSS: kaputte Zeitstempel überspringen statt den Feed zu zerstören.
+			continue
+		}
+		stamp := createdAt.UTC().Format(time.RFC3339)
+		if len(feed.Entries) == 0 { // Entries sind absteigend sortiert: der erste bestimmt <updated>.
+			feed.Updated = stamp
+		}
+
+		item := AtomEntry{
+			ID:        atomEntryID(entry.ID),
+			Title:     entry.Title,
+			Updated:   stamp,
+			Published: stamp,
+			Release:   entry.Release,
+		}
+		if author := strings.TrimSpace(entry.Author); author != "" {
+			item.Author = &AtomPerson{Name: author}
+		}
+		if link := strings.TrimSpace(entry.Link); link != "" {
+			item.Links = append(item.Links, AtomLink{Rel: "alternate", Type: "text/html", Href: link})
+		}
+		if iframe := strings.TrimSpace(entry.Iframe); iframe != "" { // Embed als verwandter Link (Atom kennt kein iframe).
+			item.Links = append(item.Links, AtomLink{Rel: "related", Type: "text/html", Href: iframe})
+		}
+		for _, enclosure := range entry.Enclosures {
+			item.Links = append(item.Links, AtomLink{Rel: "enclosure", Type: enclosure.Type, Href: enclosure.URL, Length: enclosure.Length})
+		}
+		for _, category := range entry.Categories {
+			item.Categories = append(item.Categories, AtomCategory{Term: category})
+		}
+		if content := strings.TrimSpace(entry.Content); content != "" {
+			item.Content = &AtomContent{Type: "html", Body: content}
+		}
+		feed.Entries = append(feed.Entries, item)
+	}
+
+	return writeXML(outputPath, feed)
+}
+
+func atomFeedID(site Site) string { // Feed-ID: Site-Link ist permanent genug; sonst eine feste URN.
+	if link := strings.TrimSpace(site.Link); link != "" {
+		return link
+	}
+	return "urn:wapuugotchi:feed"
+}
+
+func atomEntryID(id string) string { // Entry-IDs sind Hashes/Hex-Strings; Atom verlangt eine IRI.
+	return "urn:wapuugotchi:entry:" + strings.TrimSpace(id)
+}
+
+func resolveLink(base, file string) string { // Hängt einen Dateinamen an den Site-Link (z.B. …/feed/ + feed.atom).
+	parsed, err := url.Parse(base)
+	if err != nil {
+		return strings.TrimRight(base, "/") + "/" + file
+	}
+	if !strings.HasSuffix(parsed.Path, "/") {
+		parsed.Path += "/"
+	}
+	return parsed.ResolveReference(&url.URL{Path: file}).String()
+}
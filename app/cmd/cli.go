@@ -0,0 +1,382 @@
+package cmd // Paket "cmd": Kommandozeile – Subcommands, Flags, Hilfe und Exit-Codes.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"context"   // Abbruch bei SIGINT/SIGTERM.
+	"errors"    // flag.ErrHelp, usageError erkennen.
+	"flag"      // Globale und kommandospezifische Flags.
+	"fmt"       // Hilfe- und Fehlertexte.
+	"io"        // Stdin für "ai", Ausgabeziel der Hilfe.
+	"os"        // Stdout/Stderr, Stdin.
+	"os/signal" // Ctrl-C/SIGTERM abfangen statt hart zu beenden.
+	"strings"   // Argumente/Kategorien normalisieren.
+	"syscall"   // SIGTERM (z.B. CI-Abbruch).
+	"time"      // Datumsfilter von list.
+)
+
+const programName = "feed" // Name in Hilfe und Meldungen.
+
+const ( // Exit-Codes der CLI.
+	exitOK          = 0   // Erfolg (auch für -h/help).
+	exitError       = 1   // Fehler bei der Ausführung.
+	exitUsage       = 2   // Falscher Aufruf: unbekanntes Kommando, ungültige Flags oder Argumente.
+	exitInterrupted = 130 // Abbruch per SIGINT/SIGTERM (128 + SIGINT, wie in der Shell).
+)
+
+type runFunc func(ctx context.Context, root string, args []string) error // Führt ein Kommando mit den verbleibenden Argumenten aus.
+
+type command struct { // Ein Subcommand: Name, Hilfe und Flag-Definitionen.
+	name    string                         // Kommandoname, z.B. "update".
+	args    string                         // Argumente für die Hilfe, z.B. "<number>".
+	summary string                         // Einzeiler für die Kommandoübersicht.
+	setup   func(fs *flag.FlagSet) runFunc // Registriert die Flags und liefert die Ausführung (liest die Flag-Werte erst beim Aufruf).
+}
+
+type usageError struct{ msg string } // Falscher Aufruf => Exit-Code 2 statt 1.
+
+func (e usageError) Error() string { return e.msg }
+
+func usagef(format string, args ...any) error { // usageError mit fmt-Formatierung.
+	return usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+var commands = []command{ // Reihenfolge = Reihenfolge in der Hilfe.
+	{name: "update", summary: "fetch all providers, update data/entries.json and rebuild the feeds (default)", setup: setupUpdate},
+	{name: "build", summary: "rebuild the feeds from data/entries.json and articles/ without fetching", setup: setupBuild},
+	{name: "list", summary: "list the live entries (entries.json and articles) with their ids", setup: setupList},
+	{name: "show", args: "<id>", summary: "show one entry by id or id prefix", setup: setupShow},
+	{name: "delete", args: "<id>...", summary: "delete entries by id or id prefix and rebuild the feeds", setup: setupDelete},
+	{name: "add-article", summary: "create a new article file in articles/ with a generated id", setup: setupAddArticle},
+	{name: "validate", summary: "check site, provider, entry and article files; exits 1 on problems", setup: setupValidate},
+	{name: "ai", args: "[text...]", summary: "send text (arguments or stdin) through the configured AI backend", setup: setupAI},
+	{name: "serve", summary: "serve the generated feeds for local preview", setup: setupServe},
+	{name: "import-opml", args: "<file>", summary: "add the feeds of an OPML file as rss providers", setup: setupImportOPML},
+	{name: "export-opml", args: "[file]", summary: "write the enabled providers as OPML (default: stdout)", setup: setupExportOPML},
+}
+
+var legacyFlags = map[string]string{ // Alte Einzel-Flags → Subcommand; "go run ./app -list" funktioniert weiter.
+	"list":        "list",
+	"delete":      "delete",
+	"import-opml": "import-opml",
+	"export-opml": "export-opml",
+	"verbose":     "update",
+	"refresh-ai":  "update",
+}
+
+// Main führt die Kommandozeile aus und liefert den Exit-Code; ohne Kommando wird "update" ausgeführt.
+func Main(args []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM) // Signal bricht laufende HTTP-/KI-Calls ab.
+	defer stop()
+
+	global := flag.NewFlagSet(programName, flag.ContinueOnError)
+	global.SetOutput(os.Stderr)
+	global.Usage = func() {} // Hilfe schreiben wir selbst (nach Stdout bei -h).
+	root := global.String("root", "", "project root with data/, articles/ and the feed files (default: working directory)")
+	if err := global.Parse(legacyArgs(args)); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			printUsage(os.Stdout)
+			return exitOK
+		}
+		fmt.Fprintf(os.Stderr, "run '%s help' for usage\n", programName)
+		return exitUsage
+	}
+
+	rest := global.Args()
+	if len(rest) == 0 {
+		rest = []string{"update"}
+	}
+	name, rest := rest[0], rest[1:]
+	if name == "help" {
+		return runHelp(rest)
+	}
+	cmd, ok := lookupCommand(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\nrun '%s help' for usage\n", name, programName)
+		return exitUsage
+	}
+	return runCommand(ctx, cmd, *root, rest)
+}
+
+func runCommand(ctx context.Context, cmd command, root string, args []string) int { // Flags des Kommandos parsen, ausführen, Exit-Code bestimmen.
+	fs := flag.NewFlagSet(programName+" "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {}
+	fs.StringVar(&root, "root", root, "project root (default: working directory)") // --root auch nach dem Kommando.
+	run := cmd.setup(fs)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			printCommandUsage(os.Stdout, cmd, fs)
+			return exitOK
+		}
+		fmt.Fprintf(os.Stderr, "run '%s help %s' for usage\n", programName, cmd.name)
+		return exitUsage
+	}
+
+	err := run(ctx, root, fs.Args())
+	switch {
+	case err == nil:
+		return exitOK
+	case ctx.Err() != nil: // Abgebrochen: Meldung wie gehabt, aber eigener Exit-Code.
+		fmt.Fprintln(os.Stderr, err)
+		return exitInterrupted
+	case errors.As(err, new(usageError)):
+		fmt.Fprintf(os.Stderr, "%s\nrun '%s help %s' for usage\n", err, programName, cmd.name)
+		return exitUsage
+	default:
+		fmt.Fprintln(os.Stderr, err) // Fehler auf stderr ausgeben (CLI-Konvention).
+		return exitError
+	}
+}
+
+func runHelp(args []string) int { // "help" oder "help <command>".
+	if len(args) == 0 {
+		printUsage(os.Stdout)
+		return exitOK
+	}
+	cmd, ok := lookupCommand(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\nrun '%s help' for usage\n", args[0], programName)
+		return exitUsage
+	}
+	fs := flag.NewFlagSet(programName+" "+cmd.name, flag.ContinueOnError)
+	fs.String("root", "", "project root (default: working directory)")
+	cmd.setup(fs)
+	printCommandUsage(os.Stdout, cmd, fs)
+	return exitOK
+}
+
+func lookupCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func legacyArgs(args []string) []string { // "-list", "-delete 3", "-verbose" … → "list", "delete 3", "update -verbose".
+	i := 0
+	for i < len(args) && isRootFlag(args[i]) { // Globales --root davor bleibt stehen.
+		if !strings.Contains(args[i], "=") {
+			i++ // Wert in eigenem Argument.
+		}
+		i++
+	}
+	if i >= len(args) || !strings.HasPrefix(args[i], "-") {
+		return args
+	}
+	name, value, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+	command, ok := legacyFlags[name]
+	if !ok {
+		return args
+	}
+	fmt.Fprintf(os.Stderr, "note: -%s is deprecated, use '%s %s'\n", name, programName, command)
+	rewritten := append(append([]string{}, args[:i]...), command)
+	switch {
+	case command == "update": // Flag gehört jetzt zum Kommando.
+		rewritten = append(rewritten, args[i])
+	case hasValue: // "-delete=3"
+		rewritten = append(rewritten, value)
+	}
+	return append(rewritten, args[i+1:]...)
+}
+
+func isRootFlag(arg string) bool { // -root, --root, --root=DIR.
+	name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+	return strings.HasPrefix(arg, "-") && name == "root"
+}
+
+func printUsage(w io.Writer) { // Übersicht aller Kommandos.
+	fmt.Fprintf(w, "usage: %s [--root DIR] <command> [flags] [args]\n\n", programName)
+	fmt.Fprintln(w, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w, "  help         show help for a command")
+	fmt.Fprintf(w, "\nWithout a command, %s runs update.\n", programName)
+	fmt.Fprintf(w, "Run '%s help <command>' or '%s <command> -h' for the flags of a command.\n", programName, programName)
+	fmt.Fprintln(w, "\nexit codes: 0 ok, 1 error, 2 usage error, 130 interrupted")
+}
+
+func printCommandUsage(w io.Writer, cmd command, fs *flag.FlagSet) { // Hilfe eines Kommandos inkl. Flags.
+	fmt.Fprintf(w, "usage: %s %s [flags]", programName, cmd.name)
+	if cmd.args != "" {
+		fmt.Fprintf(w, " %s", cmd.args)
+	}
+	fmt.Fprintf(w, "\n\n%s\n\nflags:\n", cmd.summary)
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
+
+func setupUpdate(fs *flag.FlagSet) runFunc {
+	verbose := fs.Bool("verbose", false, "print progress per provider")
+	refreshAI := fs.Bool("refresh-ai", false, "ignore cached AI responses and query the model again")
+	return func(ctx context.Context, root string, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments: %s", strings.Join(args, " "))
+		}
+		return RunFeedUpdate(ctx, UpdateOptions{Root: root, Verbose: *verbose, RefreshAI: *refreshAI})
+	}
+}
+
+func setupBuild(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, root string, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments: %s", strings.Join(args, " "))
+		}
+		return RunBuild(root)
+	}
+}
+
+func setupList(fs *flag.FlagSet) runFunc {
+	var opts ListOptions
+	sources := fs.String("source", "", "only these sources (comma-separated, e.g. wordpress-releases,article)")
+	categories := fs.String("category", "", "only entries with one of these categories (comma-separated)")
+	since := fs.String("since", "", "only entries published on or after this date (YYYY-MM-DD or RFC3339)")
+	until := fs.String("until", "", "only entries published up to this date (YYYY-MM-DD inclusive, or RFC3339)")
+	fs.StringVar(&opts.Search, "search", "", "only entries whose title or content contains this text")
+	fs.StringVar(&opts.Sort, "sort", sortDate, "sort by date (newest first), title or source")
+	fs.BoolVar(&opts.Reverse, "reverse", false, "reverse the sort order")
+	fs.StringVar(&opts.Format, "format", listTable, "output format: table, json or csv")
+	return func(ctx context.Context, root string, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments: %s", strings.Join(args, " "))
+		}
+		switch opts.Sort {
+		case sortDate, sortTitle, sortSource:
+		default:
+			return usagef("unknown sort %q (use %s, %s or %s)", opts.Sort, sortDate, sortTitle, sortSource)
+		}
+		switch opts.Format {
+		case listTable, listJSON, listCSV:
+		default:
+			return usagef("unknown format %q (use %s, %s or %s)", opts.Format, listTable, listJSON, listCSV)
+		}
+		var err error
+		if opts.Since, err = parseDateFlag("since", *since, false); err != nil {
+			return err
+		}
+		if opts.Until, err = parseDateFlag("until", *until, true); err != nil {
+			return err
+		}
+		opts.Sources = cleanCategories(strings.Split(*sources, ","))
+		opts.Categories = cleanCategories(strings.Split(*categories, ","))
+		return RunListItems(root, opts)
+	}
+}
+
+func parseDateFlag(name, value string, endOfDay bool) (time.Time, error) { // YYYY-MM-DD oder RFC3339; leer => Zero.
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if day, err := time.Parse("2006-01-02", value); err == nil {
+		if endOfDay { // --until 2026-04-11 schließt den ganzen Tag ein.
+			return day.AddDate(0, 0, 1), nil
+		}
+		return day, nil
+	}
+	parsed, err := parseTime(value)
+	if err != nil {
+		return time.Time{}, usagef("invalid --%s %q (use YYYY-MM-DD or RFC3339)", name, value)
+	}
+	return parsed, nil
+}
+
+func setupShow(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, root string, args []string) error {
+		if len(args) != 1 {
+			return usagef("expected exactly one id")
+		}
+		return RunShowItem(root, args[0])
+	}
+}
+
+func setupDelete(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, root string, args []string) error {
+		if len(args) == 0 {
+			return usagef("expected at least one id")
+		}
+		return RunDeleteItems(root, args)
+	}
+}
+
+func setupAddArticle(fs *flag.FlagSet) runFunc {
+	var opts ArticleOptions
+	fs.StringVar(&opts.From, "from", "", "read the article as JSON from this file (- for stdin); flags override its fields")
+	fs.StringVar(&opts.Title, "title", "", "article title (required unless given by --from)")
+	fs.StringVar(&opts.Link, "link", "", "link to the original")
+	fs.StringVar(&opts.Content, "content", "", "article content as HTML, or Markdown with --markdown (- for stdin)")
+	fs.BoolVar(&opts.Markdown, "markdown", false, "write a .md file with front matter instead of JSON")
+	fs.StringVar(&opts.Iframe, "iframe", "", "embed URL (e.g. a YouTube embed)")
+	fs.StringVar(&opts.CreatedAt, "date", "", "publication date as RFC3339 (default: now)")
+	categories := fs.String("categories", "", "comma-separated categories")
+	return func(ctx context.Context, root string, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments: %s", strings.Join(args, " "))
+		}
+		if opts.From == "" && strings.TrimSpace(opts.Title) == "" {
+			return usagef("--title is required")
+		}
+		opts.Categories = strings.Split(*categories, ",")
+		return RunAddArticle(root, opts, os.Stdin)
+	}
+}
+
+func setupValidate(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, root string, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments: %s", strings.Join(args, " "))
+		}
+		return RunValidate(root)
+	}
+}
+
+func setupAI(fs *flag.FlagSet) runFunc {
+	pattern := fs.String("pattern", "", "prompt pattern; %s is replaced by the text (default: plain text prompt)")
+	return func(ctx context.Context, root string, args []string) error {
+		text := strings.Join(args, " ")
+		if text == "" { // Kein Argument: Text von Stdin (z.B. "echo … | feed ai").
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return err
+			}
+			text = string(data)
+		}
+		if strings.TrimSpace(text) == "" {
+			return usagef("no text given (pass it as arguments or on stdin)")
+		}
+		return RunAI(ctx, *pattern, text)
+	}
+}
+
+func setupServe(fs *flag.FlagSet) runFunc {
+	addr := fs.String("addr", defaultServeAddr, "listen address")
+	return func(ctx context.Context, root string, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments: %s", strings.Join(args, " "))
+		}
+		return RunServe(ctx, root, *addr)
+	}
+}
+
+func setupImportOPML(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, root string, args []string) error {
+		if len(args) != 1 {
+			return usagef("expected exactly one OPML file")
+		}
+		return RunImportOPML(root, args[0])
+	}
+}
+
+func setupExportOPML(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, root string, args []string) error {
+		switch len(args) {
+		case 0:
+			return RunExportOPML(root, "-")
+		case 1:
+			return RunExportOPML(root, args[0])
+		default:
+			return usagef("expected at most one output file")
+		}
+	}
+}
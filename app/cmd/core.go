@@ -0,0 +1,28 @@
+package cmd // Paket "cmd": Release-Entries mit der stable-check API abgleichen (is_latest).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"wapuugotchi/feed/app/feed" // feed.CoreVersions + Status-Werte.
+)
+
+const releaseNamespace = "urn:wapuugotchi:release" // Namespace für <wapuugotchi:release> in RSS und Atom.
+
+type coreConfig struct { // "core_versions"-Block in data/providers.json.
+	Enabled bool   `json:"enabled"`       // Release-Entries bei jedem Lauf annotieren.
+	URL     string `json:"url,omitempty"` // stable-check-Endpoint; leer => api.wordpress.org.
+}
+
+func annotateReleases(entries []Entry, versions feed.CoreVersions) bool { // Setzt is_latest für alle Release-Entries; true, wenn sich etwas geändert hat.
+	changed := false
+	for _, entry := range entries {
+		release := entry.Release
+		if release == nil {
+			continue
+		}
+		latest := !release.prerelease() && versions.Status(release.Version) == feed.VersionLatest // "7.0 RC1" ist nie die aktuelle Version, auch wenn 7.0 es ist.
+		if release.Latest != latest {
+			release.Latest = latest
+			changed = true
+		}
+	}
+	return changed
+}
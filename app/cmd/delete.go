@@ -1,44 +1,56 @@
-package cmd
+package cmd // Paket "cmd": Entries löschen (Tombstones) und die Feeds neu bauen.
 
-import (
-	"encoding/xml"
-	"fmt"
-	"os"
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"fmt" // Meldungen auf Stdout.
 )
 
-func RunDeleteItem(itemNumber int) {
-
-	var feed RSS
-
-	data, err := os.ReadFile("feed.xml")
-	if err != nil {
-		panic(err)
+// RunDeleteItems löscht Entries per ID oder ID-Präfix: aus entries.json entfernen, Tombstone in data/deleted.json
+// eintragen (damit Updates sie nicht zurückholen) und alle Ausgaben neu bauen. Artikel-Dateien bleiben liegen.
+func RunDeleteItems(root string, ids []string) error {
+	paths, err := getPaths(root) // Dateipfade unter root.
+	if err != nil {              // Root fehlt/kein Verzeichnis…
+		return err // …nichts anfassen.
 	}
-
-	err = xml.Unmarshal(data, &feed)
-	if err != nil {
-		panic(err)
+	live := liveEntries(paths) // Genau die Entries, die list anzeigt (inkl. Artikel, ohne schon gelöschte).
+
+	targets := make(deletedIDs, len(ids)) // Aufgelöste volle IDs; Set gegen doppelte Angaben.
+	var deleted []Entry                   // Gelöschte Entries in Eingabereihenfolge (für die Meldungen).
+	for _, id := range ids {              // Erst alle IDs auflösen: ein Tippfehler löscht gar nichts.
+		entry, err := findEntry(live, id) // Volle ID oder eindeutiges Präfix.
+		if err != nil {                   // Unbekannt, zu kurz oder mehrdeutig…
+			return err // …abbrechen, bevor etwas geschrieben ist.
+		}
+		if targets.has(entry.ID) { // Gleicher Entry zweimal angegeben (z.B. Präfix + volle ID).
+			continue
+		}
+		targets[entry.ID] = struct{}{}   // Merken.
+		deleted = append(deleted, entry) // Für Tombstone und Meldung.
 	}
 
-	if itemNumber < 1 || itemNumber > len(feed.Channel.Items) {
-		println("Invalid item number")
-		return
+	tombstones := loadTombstones(paths.deleted) // Vorhandene Tombstones behalten.
+	for _, entry := range deleted {             // Je gelöschtem Entry ein Tombstone mit Titel und Zeitpunkt.
+		tombstones = append(tombstones, newTombstone(entry))
 	}
-
-	titleToDelete := feed.Channel.Items[itemNumber-1].Title
-
-	feed.Channel.Items = append(feed.Channel.Items[:itemNumber-1], feed.Channel.Items[itemNumber:]...)
-
-	output, err := xml.MarshalIndent(feed, "", "  ")
-	if err != nil {
-		panic(err)
+	if err := writeJSONFile(paths.deleted, tombstones); err != nil { // Zuerst die Tombstones: sie verhindern, dass ein Update den Entry zurückholt.
+		return err
 	}
 
-	err = os.WriteFile("feed.xml", output, 0644)
-	if err != nil {
-		panic(err)
+	entries := loadEntries(paths.entries)                            // entries.json ohne Artikel.
+	if kept := targets.entries(entries); len(kept) != len(entries) { // Nur schreiben, wenn wirklich etwas rausfällt (Artikel stehen nicht drin).
+		if err := writeJSONFile(paths.entries, kept); err != nil {
+			return err
+		}
 	}
 
-	fmt.Printf("Item '%s' deleted successfully\n", titleToDelete)
-
+	if err := buildFeed(loadSite(paths.site), liveEntries(paths), paths.root); err != nil { // Alle Ausgaben ohne die gelöschten Entries.
+		return err
+	}
+	for _, entry := range deleted { // Rückmeldung pro Entry mit Kurz-ID wie in list.
+		fmt.Printf("deleted %s %s\n", shortID(entry.ID), entry.Title)
+		if entry.Source == articlesSource { // Artikel-Dateien löscht delete bewusst nicht.
+			fmt.Println("  (article file stays in articles/, the tombstone in data/deleted.json hides it)")
+		}
+	}
+	fmt.Println("feed rebuilt") // Abschlussmeldung.
+	return nil                  // Erfolg.
 }
@@ -0,0 +1,38 @@
+package cmd // Paket "cmd": Text-Auszüge aus HTML-Content (z.B. für RSS <description>).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"html"    // HTML-Entities (&amp; …) nach dem Entfernen der Tags auflösen.
+	"regexp"  // Tags und Whitespace finden.
+	"strings" // Trimmen/Kürzen.
+)
+
+const excerptLength = 280 // Maximale Länge des Text-Auszugs in Zeichen (Runes).
+
+var ( // Vorcompilierte Regexe für plainText.
+	blockTagPattern = regexp.MustCompile(`(?i)</?(p|br|li|ul|ol|h[1-6]|div|blockquote)\b[^>]*>`) // Block-Tags werden zu Leerzeichen.
+	anyTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)                                          // Alle übrigen Tags werden entfernt.
+	spacePattern    = regexp.MustCompile(`\s+`)                                                  // Whitespace zusammenfassen.
+)
+
+func plainText(content string) string { // Entfernt HTML-Tags und normalisiert Whitespace.
+	text := blockTagPattern.ReplaceAllString(content, " ") // "</p><p>" soll nicht Wörter verkleben.
+	text = anyTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+}
+
+func excerpt(content string, limit int) string { // Text-Auszug: Plain-Text, an Wortgrenze gekürzt mit "…".
+	text := plainText(content)
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	cut := runes[:limit]
+	for i := len(cut) - 1; i > limit/2; i-- { // Nicht mitten im Wort abschneiden (außer bei sehr langen Wörtern); in Runen, nicht Bytes.
+		if cut[i] == ' ' {
+			cut = cut[:i]
+			break
+		}
+	}
+	return strings.TrimRight(string(cut), " ,;:.-") + "…"
+}
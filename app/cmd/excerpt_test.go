@@ -0,0 +1,27 @@
+package cmd
+
+import "testing"
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		limit   int
+		want    string
+	}{
+		{name: "short text unchanged", content: "<p>Hello &amp; welcome</p>", limit: 20, want: "Hello & welcome"},
+		{name: "blocks become spaces", content: "<p>one</p><ul><li>two</li></ul>", limit: 20, want: "one two"},
+		{name: "cut at word boundary", content: "<p>WordPress 6.5 ships the font library</p>", limit: 20, want: "WordPress 6.5 ships…"},
+		{name: "trailing punctuation trimmed", content: "Hello, world, again and again", limit: 13, want: "Hello, world…"},
+		{name: "long word cut hard", content: "Supercalifragilistic word", limit: 10, want: "Supercalif…"},
+		{name: "space early in multibyte text", content: "äää bcdefghijkl", limit: 10, want: "äää bcdefg…"}, // Leerzeichen liegt vor der Hälfte (in Runen).
+		{name: "multibyte word boundary", content: "Grüße aus Köln und Düsseldorf", limit: 16, want: "Grüße aus Köln…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := excerpt(tt.content, tt.limit); got != tt.want {
+				t.Errorf("excerpt(%q, %d) = %q, want %q", tt.content, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
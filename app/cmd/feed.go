@@ -1,43 +1,67 @@
 package cmd // Paketname: gruppiert diesen Code als Teil des "cmd"-Pakets (typisch für CLI/Commands).
 
 import ( // Import-Block: alles, was dieser File aus der Standardlib + eigenen Modulen braucht.
+	"context"       // Abbruch des Laufs (Ctrl-C) bis in HTTP- und KI-Calls.
 	"crypto/md5"    // Für stabile Hash-IDs (Entry-ID) aus Text; wichtig fürs Deduplizieren.
 	"encoding/json" // JSON lesen/schreiben (site.json, entries.json).
 	"encoding/xml"  // RSS-XML generieren (feed.xml).
+	"errors"        // errors.Is für feed.ErrNotModified.
 	"fmt"           // Formatierte Ausgabe + Fehlertexte.
-	"io"            // io.Copy/io.Discard + io.ReadAll: Response-Body handhaben.
-	"net/http"      // HTTP-Client zum Abrufen der Feeds.
 	"os"            // Dateisystem + Stdout/Stderr + Exit.
 	"path/filepath" // OS-sichere Pfad-Konstruktion.
 	"sort"          // Sortieren der Entries nach Datum.
 	"strings"       // Trimmen/Normalisieren von Strings, wichtig bei Input aus Feeds.
 	"time"          // Zeitparser + Formate + Timeouts + Backoff.
 
+	"wapuugotchi/feed/app/ai" // KI-Paket: Antwort-Cache konfigurieren.
 	"wapuugotchi/feed/app/env"
 	"wapuugotchi/feed/app/feed" // Dein internes Paket: liefert "Latest..."-Fetcher und feed.Item Typ.
 ) // Ende Import-Block.
 
 type Site struct { // Konfiguration/Metadaten deines eigenen RSS-Feeds.
-	Title       string `json:"title"`       // Feed-Titel; JSON-Tag: Schlüssel heißt "title".
-	Link        string `json:"link"`        // Feed-Link; wichtig für RSS-Consumers.
-	Description string `json:"description"` // Feed-Beschreibung; RSS Pflicht/üblich.
+	Title       string   `json:"title"`              // Feed-Titel; JSON-Tag: Schlüssel heißt "title".
+	Link        string   `json:"link"`               // Feed-Link; wichtig für RSS-Consumers.
+	Description string   `json:"description"`        // Feed-Beschreibung; RSS Pflicht/üblich.
+	Outputs     []string `json:"outputs,omitempty"`  // Ausgabeformate (z.B. "rss", "atom"); leer => defaultOutputs.
+	RSSMode     string   `json:"rss_mode,omitempty"` // "compat" (Default) oder "strict".
 } // Ende struct Site.
 
 type Entry struct { // Persistierte Entry-Struktur (entries.json) für deinen Aggregator.
-	ID         string   `json:"id"`                   // Eindeutige ID; benutzt zur Deduplizierung.
-	Source     string   `json:"source,omitempty"`     // Quelle des Eintrags (z.B. wordpress-releases oder article).
-	Title      string   `json:"title"`                // Titel der Entry.
-	Link       string   `json:"link"`                 // URL zum Original.
-	Content    string   `json:"content"`              // Inhalt/Description im RSS.
-	Iframe     string   `json:"iframe,omitempty"`     // Optionales Embed; im RSS aktuell nicht genutzt.
-	CreatedAt  string   `json:"created_at"`           // ISO/RFC3339 Zeitstempel als String (leicht zu speichern).
-	Categories []string `json:"categories,omitempty"` // Optional: Kategorien/Tags; omitempty spart JSON wenn leer.
+	ID         string      `json:"id"`                   // Eindeutige ID; benutzt zur Deduplizierung.
+	Source     string      `json:"source,omitempty"`     // Quelle des Eintrags (z.B. wordpress-releases oder article).
+	Title      string      `json:"title"`                // Titel der Entry.
+	Link       string      `json:"link"`                 // URL zum Original.
+	Author     string      `json:"author,omitempty"`     // Autor aus der Quelle (optional).
+	Content    string      `json:"content"`              // Inhalt/Description im RSS.
+	Iframe     string      `json:"iframe,omitempty"`     // Optionales Embed (media:player im RSS).
+	CreatedAt  string      `json:"created_at"`           // ISO/RFC3339 Zeitstempel als String (leicht zu speichern).
+	Categories []string    `json:"categories,omitempty"` // Optional: Kategorien/Tags; omitempty spart JSON wenn leer.
+	Enclosures []Enclosure `json:"enclosures,omitempty"` // Angehängte Dateien aus dem Quell-Feed (Audio, Video, Bilder).
+	Release    *Release    `json:"release,omitempty"`    // Strukturierte Release-Daten (nur WordPress-Releases).
 } // Ende struct Entry.
 
+type Enclosure struct { // Angehängte Datei eines Entries.
+	URL    string `json:"url"`              // Absolute URL.
+	Type   string `json:"type,omitempty"`   // MIME-Type.
+	Length int64  `json:"length,omitempty"` // Größe in Bytes; 0 => unbekannt.
+} // Ende struct Enclosure.
+
+type Release struct { // Release-Daten eines Entries; als JSON (entries.json, feed.json) und als <wapuugotchi:release> (RSS, Atom).
+	Version  string `json:"version" xml:"version,attr"`                   // WordPress-Version aus dem Titel als MAJOR.MINOR.PATCH, z.B. "7.0.0".
+	Channel  string `json:"channel" xml:"channel,attr"`                   // stable, rc, beta, security oder maintenance.
+	Number   int    `json:"number,omitempty" xml:"number,attr,omitempty"` // RC-/Beta-Nummer ("Release Candidate 4" => 4); 0 => keine.
+	Security bool   `json:"is_security" xml:"is_security,attr"`           // Sicherheitsrelease.
+	Latest   bool   `json:"is_latest" xml:"is_latest,attr"`               // Laut stable-check die aktuelle Version.
+} // Ende struct Release.
+
 type RSS struct { // Root-Objekt für RSS 2.0 XML.
-	XMLName xml.Name `xml:"rss"`          // Setzt Root-Tag <rss>.
-	Version string   `xml:"version,attr"` // RSS-Version als Attribut: version="2.0".
-	Channel Channel  `xml:"channel"`      // Enthält <channel>...</channel>.
+	XMLName      xml.Name `xml:"rss"`                    // Setzt Root-Tag <rss>.
+	Version      string   `xml:"version,attr"`           // RSS-Version als Attribut: version="2.0".
+	XmlnsContent string   `xml:"xmlns:content,attr"`     // Namespace für content:encoded.
+	XmlnsMedia   string   `xml:"xmlns:media,attr"`       // Media-RSS-Namespace für media:content/media:player.
+	XmlnsDC      string   `xml:"xmlns:dc,attr"`          // Dublin Core für dc:creator.
+	XmlnsRelease string   `xml:"xmlns:wapuugotchi,attr"` // Eigener Namespace für wapuugotchi:release.
+	Channel      Channel  `xml:"channel"`                // Enthält <channel>...</channel>.
 } // Ende struct RSS.
 
 type Channel struct { // RSS Channel: Metadaten + Items.
@@ -49,63 +73,160 @@ type Channel struct { // RSS Channel: Metadaten + Items.
 } // Ende struct Channel.
 
 type Item struct { // RSS Item: einzelne Nachricht/Eintrag.
-	ID          string   `xml:"id"`                 // Nicht standard-RSS Feld (typisch wäre guid); bei dir <id>.
-	Title       string   `xml:"title"`              // <title>
-	Link        string   `xml:"link"`               // <link>
-	PubDate     string   `xml:"pubDate"`            // <pubDate> im RFC1123(Z) Format.
-	Description string   `xml:"description"`        // <description> (bei dir Content).
-	Iframe      string   `xml:"iframe,omitempty"`   // Optionales <iframe>-Feld (custom XML).
-	Categories  []string `xml:"category,omitempty"` // <category> mehrfach möglich; weglassen wenn leer.
+	ID             string        `xml:"id,omitempty"`                  // Legacy: nicht standard-RSS; nur im Kompatibilitätsmodus.
+	GUID           *GUID         `xml:"guid,omitempty"`                // <guid isPermaLink="false"> aus Entry.ID.
+	Title          string        `xml:"title"`                         // <title>
+	Link           string        `xml:"link"`                          // <link>
+	Creator        string        `xml:"dc:creator,omitempty"`          // Autor (RSS <author> verlangt eine E-Mail-Adresse).
+	PubDate        string        `xml:"pubDate"`                       // <pubDate> im RFC1123(Z) Format.
+	Description    string        `xml:"description"`                   // <description>: Text-Auszug (Kompatibilitätsmodus: volles HTML).
+	ContentEncoded *CDATA        `xml:"content:encoded,omitempty"`     // Volles HTML als CDATA.
+	Iframe         string        `xml:"iframe,omitempty"`              // Legacy: optionales <iframe>-Feld (custom XML).
+	Media          *MediaContent `xml:"media:content,omitempty"`       // Embed als Media-RSS-Player.
+	Enclosure      *RSSEnclosure `xml:"enclosure,omitempty"`           // Erste angehängte Datei (RSS 2.0 erlaubt nur eine).
+	Release        *Release      `xml:"wapuugotchi:release,omitempty"` // Release-Daten (Version, Kanal, …).
+	Categories     []string      `xml:"category,omitempty"`            // <category> mehrfach möglich; weglassen wenn leer.
 } // Ende struct Item.
 
+type GUID struct { // <guid>: Entry-ID ist kein Link, daher isPermaLink="false".
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+} // Ende struct GUID.
+
+type CDATA struct { // Schreibt den Inhalt als <![CDATA[...]]> statt entity-escaped.
+	Value string `xml:",cdata"`
+} // Ende struct CDATA.
+
+type MediaContent struct { // <media:content> mit <media:player> für Video-Embeds.
+	URL    string      `xml:"url,attr"`              // Embed-URL.
+	Medium string      `xml:"medium,attr,omitempty"` // z.B. "video".
+	Type   string      `xml:"type,attr,omitempty"`   // MIME-Type der URL (Embed-Seite = text/html).
+	Player MediaPlayer `xml:"media:player"`          // Player-URL (gleich dem Embed).
+} // Ende struct MediaContent.
+
+type MediaPlayer struct { // <media:player url="..."/>.
+	URL string `xml:"url,attr"`
+} // Ende struct MediaPlayer.
+
+type RSSEnclosure struct { // <enclosure url="..." length="..." type="..."/>; length und type sind laut Spezifikation Pflicht.
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+} // Ende struct RSSEnclosure.
+
 type Paths struct { // Kleine Struktur: bündelt zusammengehörige Dateipfade.
-	site     string // Pfad zu site.json.
-	entries  string // Pfad zu entries.json.
-	articles string // Pfad zu Artikeldateien (manuelle Inhalte).
-	feed     string // Pfad zur Ausgabe feed.xml.
+	site      string // Pfad zu site.json.
+	entries   string // Pfad zu entries.json.
+	deleted   string // Pfad zu deleted.json (Tombstones).
+	articles  string // Pfad zu Artikeldateien (manuelle Inhalte).
+	providers string // Pfad zu providers.json (Quellen-Konfiguration).
+	aiCache   string // Verzeichnis des KI-Antwort-Caches.
+	prompts   string // Verzeichnis der Prompt-Templates (*.tmpl).
+	httpCache string // Verzeichnis des HTTP-Caches (ETag/Last-Modified/Body).
+	root      string // Projektroot: hier landen die Ausgaben (feed.xml, feed.atom, …).
 } // Ende struct paths.
 
 const ( // Konstanten: zentrale HTTP Header-Defaults.
+	contentNamespace = "http://purl.org/rss/1.0/modules/content/"                                                                        // RSS-Modul für content:encoded.
+	dcNamespace      = "http://purl.org/dc/elements/1.1/"                                                                                // Dublin Core (dc:creator).
+	mediaNamespace   = "http://search.yahoo.com/mrss/"                                                                                   // Media RSS.
+	rssModeCompat    = "compat"                                                                                                          // Standard + Legacy-Elemente (<id>, <iframe>, HTML-description) für alte Plugin-Versionen.
+	rssModeStrict    = "strict"                                                                                                          // Nur standardkonforme Elemente.
 	userAgent        = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36" // Tarnung/Kompatibilität; manche Server blocken Default-Go-Agent.
 	acceptHeader     = "application/rss+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.7"                                           // Akzeptierte Response-Formate; hilft bei Content Negotiation.
 	releasesProvider = "wordpress-releases"
 	articlesSource   = "article"
 ) // Ende const.
 
-func RunFeedUpdate(verbose bool) error { // Hauptfunktion: lädt Daten, holt neue Items, schreibt files, baut feed.xml.
-	paths, err := getPaths() // Ermittelt Pfade für site.json, entries.json, feed.xml relativ zum CWD.
-	if err != nil {          // Wenn getPaths scheitert (z.B. kein CWD), abbrechen.
+type UpdateOptions struct { // Schalter für RunFeedUpdate (aus CLI-Flags).
+	Root      string // Projektroot (--root); leer => Working Directory.
+	Verbose   bool   // Fortschritt pro Provider ausgeben.
+	RefreshAI bool   // KI-Cache ignorieren und alle Prompts neu anfragen.
+} // Ende struct UpdateOptions.
+
+func RunFeedUpdate(ctx context.Context, opts UpdateOptions) error { // Hauptfunktion: lädt Daten, holt neue Items, schreibt files, baut feed.xml.
+	verbose := opts.Verbose           // Kurzform, wird im ganzen Lauf genutzt.
+	paths, err := getPaths(opts.Root) // Ermittelt Pfade für site.json, entries.json, feed.xml unter dem Projektroot.
+	if err != nil {                   // Wenn getPaths scheitert (z.B. kein CWD), abbrechen.
 		return err // Fehler nach außen geben.
 	} // Ende error-check.
 
-	site := loadSite(paths.site)          // Lädt Site-Metadaten; liefert Defaults wenn Datei fehlt.
-	entries := loadEntries(paths.entries) // Lädt bisher bekannte Einträge (für Dedupe + Historie).
+	ttl, err := aiCacheTTL() // TTL des KI-Caches aus AI_CACHE_TTL (z.B. "720h").
+	if err != nil {
+		return err
+	}
+	ai.ConfigureCache(ai.CacheOptions{Dir: paths.aiCache, TTL: ttl, Refresh: opts.RefreshAI}) // Gleiche Prompts → gleiche Antwort, ohne erneuten API-Call.
+
+	site := loadSite(paths.site)             // Lädt Site-Metadaten; liefert Defaults wenn Datei fehlt.
+	entries := loadEntries(paths.entries)    // Lädt bisher bekannte Einträge (für Dedupe + Historie).
+	deleted := loadDeletedIDs(paths.deleted) // Per "delete" entfernte IDs: nicht erneut übernehmen.
 
-	updated := false                       // Flag: ob neue Entries hinzugekommen sind.
-	for _, provider := range providers() { // Iteriert über alle Feed-Quellen (provider).
-		if verbose {
-			fmt.Printf("Processing feed: %s\n", provider.Name)
+	config, err := loadProvidersFile(paths.providers) // Lädt data/providers.json (oder eingebaute Defaults).
+	if err != nil {                                   // Kaputte Konfiguration: lieber abbrechen als still nichts tun.
+		return err
+	}
+	configs := config.Providers                     // Quellen; config.Fetch steuert HTTP/Retry, config.Core den Versionsabgleich.
+	prompts, err := feed.LoadPrompts(paths.prompts) // prompts/*.tmpl, sonst eingebaute Prompts.
+	if err != nil {
+		return err
+	}
+	active, err := providers(configs, prompts) // Aktivierte Quellen mit aufgelöstem Parser + Prompt.
+	if err != nil {
+		return err
+	}
+
+	fetcher := newFetcher(paths.httpCache, config.Fetch, verbose)                                            // Ein Fetcher für den ganzen Lauf (Client, Retry-Policy, HTTP-Cache).
+	results := fetchProviders(ctx, active, entries, deleted, fetcher.fetch, config.Fetch.workers(), verbose) // Parallel abrufen + transformieren; Ergebnisse in Provider-Reihenfolge.
+
+	var versions feed.CoreVersions // Status je WordPress-Version; nil => Release-Daten bleiben, wie sie sind.
+	if config.Core.Enabled {
+		versions, err = feed.LatestCoreVersions(ctx, config.Core.URL, fetcher.fetchCurrent)
+		if err != nil && ctx.Err() == nil { // Nicht fatal: die Einträge selbst sind wichtiger als ihre Annotation.
+			fmt.Fprintln(os.Stderr, err)
 		}
-		added, err := addLatest(provider, &entries) // Holt "latest item" pro Provider und fügt es ggf. hinzu.
-		if err != nil {                             // Wenn dieser Provider fehlschlägt…
-			fmt.Fprintln(os.Stderr, err) // …Fehler loggen, aber nicht den gesamten Run abbrechen.
-			continue                     // Weiter mit nächstem Provider.
-		} // Ende provider-error.
-		if added { // Wenn tatsächlich ein neuer Entry hinzugefügt wurde…
+	}
+
+	if err := ctx.Err(); err != nil { // Abgebrochen (SIGINT/SIGTERM): nichts speichern, keine Ausgaben schreiben.
+		return fmt.Errorf("update interrupted: %w", err)
+	}
+
+	updated := refreshReleases(entries) // Flag: ob entries.json neu geschrieben werden muss; Release-Daten älterer Entries nachtragen.
+	for i, provider := range active {   // Merge nur hier (ein Schreiber), in Konfigurationsreihenfolge.
+		if results[i].err != nil { // Wenn dieser Provider (ganz oder teilweise) fehlschlägt…
+			fmt.Fprintln(os.Stderr, results[i].err) // …Fehler loggen, aber nicht den gesamten Run abbrechen.
+		} // Ende provider-error; Teilergebnisse werden trotzdem übernommen.
+		if addLatest(provider, results[i].items, &entries) { // Wenn tatsächlich ein neuer Entry hinzugefügt wurde…
 			updated = true // …merken, dass wir speichern + XML rebuilden müssen.
 		} // Ende added-check.
 	} // Ende provider-loop.
+	if versions != nil && annotateReleases(entries, versions) { // is_latest ändert sich auch für alte Entries, sobald eine neue Version erscheint.
+		updated = true
+	}
+	if pruned := applyRetention(&entries, configs); pruned > 0 { // Alte Entries pro Quelle gemäß Retention-Policy entfernen.
+		if verbose {
+			fmt.Printf("Retention removed %d entries\n", pruned)
+		}
+		updated = true // Auch reines Aufräumen muss persistiert werden.
+	}
 	if updated {
 		saveEntries(paths.entries, entries) // Persistiert aktualisierte entries.json.
 		fmt.Println("provider update detected")
 	} else {
 		fmt.Println("no provider update detected")
 	}
+	for i := range active { // HTTP-Cache erst jetzt: ein abgebrochener Lauf darf beim nächsten Mal kein 304 bekommen.
+		if results[i].err == nil { // Bei Fehlern (auch teilweisen) fragt der nächste Lauf alles neu ab.
+			fetcher.cache.commit(results[i].urls)
+		}
+	}
 
 	manualArticles := loadArticleEntries(paths.articles)
-	allEntries := mergeEntries(entries, manualArticles)
+	if versions != nil { // Artikel zu Releases bekommen dasselbe is_latest wie Provider-Entries.
+		annotateReleases(manualArticles, versions)
+	}
+	allEntries := mergeEntries(deleted.entries(entries), deleted.entries(manualArticles)) // Von Hand eingetragene Tombstones gelten auch für Bestand und Artikel.
 
-	if err := buildFeed(site, allEntries, paths.feed); err != nil { // Baut feed.xml neu (RSS).
+	if err := buildFeed(site, allEntries, paths.root); err != nil { // Baut alle konfigurierten Ausgaben neu (RSS, Atom, …).
 		return err // Fehler beim Schreiben/Encoding nach außen geben.
 	} // Ende buildFeed error-check.
 
@@ -113,40 +234,67 @@ func RunFeedUpdate(verbose bool) error { // Hauptfunktion: lädt Daten, holt neu
 	return nil                  // Erfolg.
 } // Ende RunFeedUpdate.
 
-type feedProvider struct { // Abstraktion einer Quelle: Name + Fetch-Funktion.
-	Name  string                                                                  // Name wird u.a. in ID-Hash einbezogen (stabil pro Quelle).
-	Fetch func(fetch func(url, source string) ([]byte, error)) (feed.Item, error) // Fetcher nimmt eine fetch-Funktion (Dependency Injection) und liefert ein feed.Item.
-} // Ende struct feedProvider.
-
-func providers() []feedProvider { // Liefert die Liste der Quellen, die abgefragt werden sollen.
-	return []feedProvider{ // Slice-Literal: Reihenfolge ist die Abfrage-Reihenfolge.
-		{Name: releasesProvider, Fetch: feed.LatestReleases}, // Quelle 1: WordPress Releases.
-		// 		{Name: "wordpress-tv", Fetch: feed.LatestWordPressTV},       // Quelle 2: WordPress TV.
-		// 		{Name: "wordpress-com", Fetch: feed.LatestWordPressComBlog}, // Quelle 3: WordPress.com Blog.
-	} // Ende Slice.
-} // Ende providers.
-
-func getPaths() (Paths, error) { // Ermittelt, wo Dateien liegen sollen (relativ zum Working Directory).
-	root, err := os.Getwd() // Holt das aktuelle Arbeitsverzeichnis.
-	if err != nil {         // Falls das nicht geht (selten, aber möglich)…
+func RunBuild(root string) error { // Baut alle Ausgaben aus entries.json + Artikeln neu, ohne Quellen abzufragen.
+	paths, err := getPaths(root)
+	if err != nil {
+		return err
+	}
+	if err := buildFeed(loadSite(paths.site), liveEntries(paths), paths.root); err != nil {
+		return err
+	}
+	fmt.Println("feed rebuilt")
+	return nil
+} // Ende RunBuild.
+
+func aiCacheTTL() (time.Duration, error) { // Liest AI_CACHE_TTL; leer => Einträge laufen nie ab.
+	value := env.ReadEnv("AI_CACHE_TTL")
+	if value == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("AI_CACHE_TTL: %w", err)
+	}
+	return ttl, nil
+} // Ende aiCacheTTL.
+
+func getPaths(root string) (Paths, error) { // Ermittelt, wo Dateien liegen sollen (unter root; leer => Working Directory).
+	if strings.TrimSpace(root) == "" { // Kein --root: wie bisher das aktuelle Arbeitsverzeichnis.
+		root = "."
+	} // Ende root-default.
+	root, err := filepath.Abs(root) // Absolut machen, damit Meldungen eindeutige Pfade zeigen.
+	if err != nil {                 // Falls das nicht geht (z.B. kein CWD)…
 		return Paths{}, err // …leere paths + Fehler zurück.
 	} // Ende error-check.
+	if info, err := os.Stat(root); err != nil || !info.IsDir() { // Tippfehler in --root früh melden statt leere Dateien anzulegen.
+		return Paths{}, fmt.Errorf("root %s: not a directory", root)
+	} // Ende dir-check.
 	dataDir := filepath.Join(root, "data") // Baut data/ Pfad OS-sicher zusammen.
 	return Paths{                          // Gibt alle Pfade zurück.
-		site:     filepath.Join(dataDir, "site.json"),    // data/site.json
-		entries:  filepath.Join(dataDir, "entries.json"), // data/entries.json
-		articles: filepath.Join(root, "articles"),        // articles/ (manuell gepflegte Beiträge)
-		feed:     filepath.Join(root, "feed.xml"),        // feed.xml im Projektroot.
+		site:      filepath.Join(dataDir, "site.json"),      // data/site.json
+		entries:   filepath.Join(dataDir, "entries.json"),   // data/entries.json
+		deleted:   filepath.Join(dataDir, "deleted.json"),   // data/deleted.json (Tombstones gelöschter Entries)
+		articles:  filepath.Join(root, "articles"),          // articles/ (manuell gepflegte Beiträge)
+		providers: filepath.Join(dataDir, "providers.json"), // data/providers.json
+		aiCache:   filepath.Join(dataDir, "ai-cache"),       // data/ai-cache/ (ein JSON pro Prompt-Hash)
+		prompts:   filepath.Join(root, "prompts"),           // prompts/ (editierbare Prompt-Templates)
+		httpCache: filepath.Join(root, ".cache", "http"),    // .cache/http/ (ein JSON pro URL inkl. Body; nicht im Repo, CI nutzt actions/cache)
+		root:      root,                                     // Ausgaben liegen im Projektroot.
 	}, nil // Kein Fehler.
 } // Ende getPaths.
 
 func loadSite(path string) Site { // Lädt Site-Infos mit sinnvollem Default.
 	site := Site{Title: "Wapuugotchi RSS"} // Default-Wert; wichtig falls site.json fehlt/leer ist.
-	if fillSiteFromEnv(&site) {
-		return site
+	if !fillSiteFromEnv(&site) {           // Env hat Vorrang vor site.json.
+		readJSON(path, &site) // Versucht zu überschreiben; bei Fehlern macht readJSON einfach nichts.
+	}
+	if outputs := env.ReadEnv("FEED_OUTPUTS"); outputs != "" { // Ausgabeformate per Env überschreibbar, z.B. "rss,atom".
+		site.Outputs = strings.Split(outputs, ",")
+	}
+	if mode := env.ReadEnv("FEED_RSS_MODE"); mode != "" { // RSS-Modus per Env überschreibbar.
+		site.RSSMode = mode
 	}
-	readJSON(path, &site) // Versucht zu überschreiben; bei Fehlern macht readJSON einfach nichts.
-	return site           // Gibt Site zurück (Default oder geladen).
+	return site // Gibt Site zurück (Default oder geladen).
 } // Ende loadSite.
 
 func loadEntries(path string) []Entry { // Lädt gespeicherte Entries.
@@ -173,59 +321,100 @@ func fillSiteFromEnv(site *Site) bool { // Lädt alle env variablen
 
 } // Ende fillSiteFromEnv.
 
-func addLatest(provider feedProvider, entries *[]Entry) (bool, error) { // Holt neuesten Item eines Providers und fügt ihn ggf. hinzu.
-	item, err := provider.Fetch(fetchFeed) // Provider-Fetcher aufrufen; bekommt fetchFeed als HTTP-Funktion.
-	if err != nil {                        // Wenn Fetch scheitert…
-		return false, err // …nichts hinzugefügt + Fehler.
+func latestItems(ctx context.Context, provider feedProvider, entries []Entry, deleted deletedIDs, fetch feed.Fetcher) ([]feed.Item, error) { // Holt alle neuen Items eines Providers (ohne entries anzufassen).
+	source := provider.Source                       // Kopie: Since/Seen gelten nur für diesen Lauf.
+	source.Since = lastSeen(entries, provider.Name) // Nur Items neuer als der letzte Entry dieser Quelle abfragen.
+	source.Seen = func(guid string) bool {          // Parser mit GUIDs (z.B. Plugin-Versionen) fragen vor dem KI-Call nach.
+		id := pickEntryID(provider.Name, feed.Item{GUID: guid})
+		return idExists(entries, id) || deleted.has(id)
+	}
+	items, err := provider.Fetch(ctx, source, fetch) // Parser aufrufen; bekommt Source + fetch als HTTP-Funktion.
+	if errors.Is(err, feed.ErrNotModified) {         // 304: Quelle unverändert…
+		return nil, nil // …kein Fehler, nur nichts Neues.
+	}
+	if err != nil && len(items) == 0 { // Wenn Fetch scheitert…
+		return nil, err // …nichts hinzugefügt + Fehler.
 	} // Ende error-check.
+
+	items = deleted.items(provider.Name, items)   // Gelöschte Entries kommen nicht zurück, auch wenn die Quelle sie noch liefert.
+	sort.SliceStable(items, func(i, j int) bool { // Älteste zuerst: so landet bei Releases am Ende der neueste Stand.
+		return pickEntryTime(items[i]) < pickEntryTime(items[j]) // RFC3339-Strings sind lexikographisch sortierbar.
+	})
+	return items, err // Teilergebnis (z.B. ein Plugin-Slug scheitert): Items übernehmen, Fehler trotzdem melden.
+} // Ende latestItems.
+
+func addLatest(provider feedProvider, items []feed.Item, entries *[]Entry) bool { // Merged die Items eines Providers in entries.
+	added := false               // Flag: ob mindestens ein Item neu war.
+	for _, item := range items { // Parser liefert höchstens MaxItems neue Items.
+		if addItem(provider, item, entries) {
+			added = true
+		}
+	}
+	return added
+} // Ende addLatest.
+
+func addItem(provider feedProvider, item feed.Item, entries *[]Entry) bool { // Fügt ein einzelnes Item als Entry hinzu (mit Dedupe).
 	if strings.TrimSpace(item.Title) == "" { // Wenn Item ohne Titel kommt…
-		return false, nil // …ignorieren: vermutlich ungültig/leer.
+		return false // …ignorieren: vermutlich ungültig/leer.
 	} // Ende title-check.
 
-	item.Categories = cleanCategories(item.Categories) // Kategorien trimmen + leere entfernen.
-	id := pickEntryID(provider.Name, item)             // Stabile ID aus Provider + PubDate/Link generieren.
+	item.Categories = appendCategories(cleanCategories(item.Categories), provider.Categories) // Kategorien trimmen + konfigurierte Kategorien ergänzen.
+	id := pickEntryID(provider.Name, item)                                                    // Stabile ID aus Provider + PubDate/Link generieren.
 	newEntry := Entry{
 		ID:         id,
 		Source:     provider.Name,
 		Title:      item.Title,
 		Link:       item.Link,
+		Author:     strings.TrimSpace(item.Author),
 		Content:    item.Content,
+		Iframe:     strings.TrimSpace(item.Iframe),
 		CreatedAt:  pickEntryTime(item),
 		Categories: item.Categories,
+		Enclosures: entryEnclosures(item.Enclosures),
 	}
-
-	if provider.Name == releasesProvider {
-		return replaceWithLatestRelease(entries, newEntry), nil
-	}
+	newEntry.Release = parseRelease(newEntry) // Braucht Titel, Quelle und Kategorien des fertigen Entries.
 
 	if idExists(*entries, id) { // Prüfen, ob diese ID schon vorhanden ist.
-		return false, nil // Wenn ja: kein Update.
+		return false // Wenn ja: kein Update.
 	} // Ende exists-check.
 
 	*entries = append(*entries, newEntry) // Neuen Entry an den Slice anhängen (über Pointer mutieren).
-	return true, nil                      // Es wurde etwas hinzugefügt.
-} // Ende addLatest.
-
-func replaceWithLatestRelease(entries *[]Entry, latest Entry) bool {
-	if len(*entries) == 1 && isReleaseEntry((*entries)[0]) && (*entries)[0].ID == latest.ID {
-		return false
+	return true                           // Es wurde etwas hinzugefügt.
+} // Ende addItem.
+
+func entryEnclosures(enclosures []feed.Enclosure) []Enclosure { // Übernimmt die Dateien eines Items in den Entry.
+	var result []Enclosure
+	for _, enclosure := range enclosures {
+		if url := strings.TrimSpace(enclosure.URL); url != "" {
+			result = append(result, Enclosure{URL: url, Type: strings.TrimSpace(enclosure.Type), Length: enclosure.Length})
+		}
 	}
-	*entries = []Entry{latest}
-	return true
-}
+	return result
+} // Ende entryEnclosures.
 
-func isReleaseEntry(entry Entry) bool {
-	if strings.TrimSpace(entry.Source) == releasesProvider {
-		return true
+func enclosureType(enclosure Enclosure) string { // MIME-Type mit neutralem Default (RSS verlangt das Attribut).
+	if enclosure.Type != "" {
+		return enclosure.Type
 	}
-	for _, category := range entry.Categories {
-		switch strings.ToLower(strings.TrimSpace(category)) {
-		case "release", "releases":
-			return true
+	return "application/octet-stream"
+} // Ende enclosureType.
+
+func lastSeen(entries []Entry, source string) time.Time { // Neuester CreatedAt-Zeitpunkt aller Entries einer Quelle.
+	var latest time.Time
+	for _, entry := range entries {
+		if entry.Source != source {
+			continue
+		}
+		createdAt, err := parseTime(entry.CreatedAt)
+		if err != nil { // Kaputte Zeitstempel zählen nicht als "gesehen".
+			continue
+		}
+		if createdAt.After(latest) {
+			latest = createdAt
 		}
 	}
-	return false
-}
+	return latest // Zero-Time, wenn die Quelle noch keine Entries hat.
+} // Ende lastSeen.
 
 func mergeEntries(base, extra []Entry) []Entry {
 	if len(extra) == 0 {
@@ -259,18 +448,25 @@ func loadArticleEntries(dir string) []Entry {
 	}
 	entries := make([]Entry, 0, len(list))
 	for _, file := range list {
-		if file.IsDir() || !strings.HasSuffix(strings.ToLower(file.Name()), ".json") {
+		if file.IsDir() || !isArticleFile(file.Name()) {
 			continue
 		}
 
 		path := filepath.Join(dir, file.Name())
 		data, err := os.ReadFile(path)
 		if err != nil {
+			fmt.Fprintf(os.Stderr, "skipped article %s: %v\n", path, err)
 			continue
 		}
 
 		var entry Entry
-		if err := json.Unmarshal(data, &entry); err != nil {
+		if isMarkdownArticle(file.Name()) {
+			entry, _, err = parseMarkdownArticle(data) // Unbekannte Schlüssel meldet validate.
+		} else {
+			err = json.Unmarshal(data, &entry)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skipped article %s: %v\n", path, err)
 			continue
 		}
 
@@ -282,60 +478,29 @@ func loadArticleEntries(dir string) []Entry {
 		entry.Categories = cleanCategories(entry.Categories)
 		entry.Source = articlesSource
 
-		if entry.Title == "" || entry.CreatedAt == "" {
-			continue
-		}
-		if _, err := parseTime(entry.CreatedAt); err != nil {
+		if problems := checkArticle(path, entry); len(problems) > 0 { // Gleiche Regel wie validate: nichts veröffentlichen, was validate ablehnt.
+			fmt.Fprintf(os.Stderr, "skipped article %s: invalid %s (run validate for details)\n", path, strings.Join(problemFields(problems), ", "))
 			continue
 		}
 		if strings.TrimSpace(entry.ID) == "" {
 			entry.ID = hashString("article|" + file.Name() + "|" + entry.Link + "|" + entry.CreatedAt)
 		}
+		if entry.Release == nil { // Explizite Release-Daten im Artikel haben Vorrang.
+			entry.Release = parseRelease(entry)
+		}
 
 		entries = append(entries, entry)
 	}
 	return entries
 }
 
-func fetchFeed(url, source string) ([]byte, error) { // HTTP Fetch helper mit Retry auf 429.
-	client := &http.Client{Timeout: 15 * time.Second} // Client mit Timeout; schützt vor Hängern.
-
-	var body []byte                            // Hier landet der Response-Body.
-	for attempt := 0; attempt < 2; attempt++ { // Max 2 Versuche: 1 normal + 1 Retry bei 429.
-		req, err := http.NewRequest(http.MethodGet, url, nil) // Request bauen.
-		if err != nil {                                       // Wenn URL kaputt o.ä.
-			return nil, err // Direkt zurück.
-		} // Ende error-check.
-		req.Header.Set("User-Agent", userAgent) // Setzt User-Agent.
-		req.Header.Set("Accept", acceptHeader)  // Setzt Accept Header.
-
-		resp, err := client.Do(req) // Request ausführen.
-		if err != nil {             // Netzwerkfehler, DNS, Timeout, etc.
-			return nil, err // Zurückgeben.
-		} // Ende error-check.
-
-		if resp.StatusCode == http.StatusTooManyRequests && attempt == 0 { // Wenn 429 und wir sind beim ersten Versuch…
-			_, _ = io.Copy(io.Discard, resp.Body) // Body leeren, damit Keep-Alive sauber ist (best practice).
-			resp.Body.Close()                     // Body schließen (wichtig: Ressourcen frei).
-			time.Sleep(2 * time.Second)           // Kurzer Backoff bevor Retry.
-			continue                              // Nächster Versuch.
-		} // Ende 429-Handling.
-
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 { // Alles außerhalb 2xx als Fehler behandeln.
-			resp.Body.Close()                                                // Body schließen, sonst Leak.
-			return nil, fmt.Errorf("%s api status: %s", source, resp.Status) // Fehler mit Quelle + Status.
-		} // Ende status-check.
-
-		body, err = io.ReadAll(resp.Body) // Body vollständig lesen.
-		resp.Body.Close()                 // Immer schließen, auch bei Erfolg.
-		if err != nil {                   // Falls ReadAll fehlschlägt…
-			return nil, err // …Fehler zurück.
-		} // Ende read error-check.
-		break // Erfolgreich gelesen: Retry-Schleife verlassen.
-	} // Ende retry-loop.
-
-	return body, nil // Gibt Response-Bytes zurück.
-} // Ende fetchFeed.
+func isArticleFile(name string) bool { // articles/*.json oder articles/*.md.
+	return strings.HasSuffix(strings.ToLower(name), ".json") || isMarkdownArticle(name)
+}
+
+func isMarkdownArticle(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".md")
+}
 
 func cleanCategories(values []string) []string { // Entfernt Whitespace + leere Kategorien.
 	result := make([]string, 0, len(values)) // Prealloc: spart Reallocs, max so groß wie input.
@@ -349,10 +514,50 @@ func cleanCategories(values []string) []string { // Entfernt Whitespace + leere
 	return result // Ergebnis zurück.
 } // Ende cleanCategories.
 
-func buildFeed(site Site, entries []Entry, outputPath string) error { // Baut feed.xml aus Site + Entries.
-	sort.Slice(entries, func(i, j int) bool { // Sortiert Entries absteigend nach CreatedAt-String.
-		return entries[i].CreatedAt > entries[j].CreatedAt // Stringvergleich funktioniert bei RFC3339 (lexikographisch = chronologisch).
-	}) // Ende sort.
+func appendCategories(values, extra []string) []string { // Hängt konfigurierte Kategorien an, ohne Duplikate.
+	for _, value := range extra {
+		if !containsFold(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+} // Ende appendCategories.
+
+func containsFold(values []string, value string) bool { // Case-insensitive Suche in einem String-Slice.
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+} // Ende containsFold.
+
+func slugify(title, fallback string) string { // "Make WordPress Core" → "make-wordpress-core" (Provider-Namen, Artikel-Dateinamen).
+	var out strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			out.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && out.Len() > 0 { // Alles andere wird zu genau einem Bindestrich.
+			out.WriteByte('-')
+			dash = true
+		}
+	}
+	if slug := strings.TrimSuffix(out.String(), "-"); slug != "" {
+		return slug
+	}
+	return fallback // Titel ohne ASCII-Buchstaben/Ziffern.
+} // Ende slugify.
+
+func writeRSS(site Site, entries []Entry, outputPath string) error { // Baut feed.xml (RSS 2.0) aus Site + absteigend sortierten Entries.
+	mode, err := rssMode(site)
+	if err != nil {
+		return err
+	}
+	legacy := mode == rssModeCompat // Legacy-Elemente für bestehende Plugin-Versionen mitschreiben?
 
 	channel := Channel{ // Channel-Metadaten setzen.
 		Title:       site.Title,       // Feed Titel.
@@ -372,22 +577,59 @@ func buildFeed(site Site, entries []Entry, outputPath string) error { // Baut fe
 		if err != nil {                              // Wenn kaputt…
 			continue // Entry überspringen (besser als kompletten Feed kaputt machen).
 		} // Ende parse error.
-		channel.Items = append(channel.Items, Item{ // Item hinzufügen.
-			Title:       entry.Title,                           // Titel.
-			Link:        entry.Link,                            // Link.
-			ID:          entry.ID,                              // ID (bei dir <id>).
-			PubDate:     createdAt.UTC().Format(time.RFC1123Z), // pubDate in RFC1123Z.
-			Description: entry.Content,                         // description = content.
-			Iframe:      strings.TrimSpace(entry.Iframe),       // Optionales iframe-Feld.
-			Categories:  entry.Categories,                      // Kategorien.
-		}) // Ende append.
+		item := Item{ // Item aufbauen.
+			GUID:        &GUID{IsPermaLink: "false", Value: entry.ID}, // guid = Entry-ID (kein Link).
+			Title:       entry.Title,                                  // Titel.
+			Link:        entry.Link,                                   // Link.
+			Creator:     entry.Author,                                 // Autor (optional).
+			PubDate:     createdAt.UTC().Format(time.RFC1123Z),        // pubDate in RFC1123Z.
+			Description: excerpt(entry.Content, excerptLength),        // description = Text-Auszug.
+			Categories:  entry.Categories,                             // Kategorien.
+			Release:     entry.Release,                                // Release-Daten (nil => kein Element).
+		} // Ende item init.
+		if content := strings.TrimSpace(entry.Content); content != "" { // Volles HTML nur, wenn vorhanden.
+			item.ContentEncoded = &CDATA{Value: content}
+		} // Ende content-check.
+		iframe := strings.TrimSpace(entry.Iframe) // Optionales Embed.
+		if iframe != "" {                         // Embed als Media-RSS-Player ausdrücken.
+			item.Media = &MediaContent{URL: iframe, Medium: "video", Type: "text/html", Player: MediaPlayer{URL: iframe}}
+		} // Ende iframe-check.
+		if len(entry.Enclosures) > 0 { // Nur die erste Datei; Atom/JSON Feed tragen alle.
+			enclosure := entry.Enclosures[0]
+			item.Enclosure = &RSSEnclosure{URL: enclosure.URL, Length: enclosure.Length, Type: enclosureType(enclosure)}
+		} // Ende enclosure-check.
+		if legacy { // Kompatibilitätsmodus: alte Plugin-Versionen lesen <id>, <iframe> und HTML in <description>.
+			item.ID = entry.ID
+			item.Iframe = iframe
+			item.Description = entry.Content
+		} // Ende legacy-check.
+		channel.Items = append(channel.Items, item) // Item hinzufügen.
 	} // Ende loop.
 
 	rss := RSS{ // RSS Root erstellen.
-		Version: "2.0",   // RSS Version setzen.
-		Channel: channel, // Channel einhängen.
+		Version:      "2.0",            // RSS Version setzen.
+		XmlnsContent: contentNamespace, // content:encoded deklarieren.
+		XmlnsMedia:   mediaNamespace,   // media:* deklarieren.
+		XmlnsDC:      dcNamespace,      // dc:creator deklarieren.
+		XmlnsRelease: releaseNamespace, // wapuugotchi:release deklarieren.
+		Channel:      channel,          // Channel einhängen.
 	} // Ende rss init.
 
+	return writeXML(outputPath, rss) // RSS struct als XML schreiben.
+} // Ende writeRSS.
+
+func rssMode(site Site) (string, error) { // Normalisierter RSS-Modus; leer => Kompatibilität.
+	mode := strings.ToLower(strings.TrimSpace(site.RSSMode))
+	if mode == "" {
+		mode = rssModeCompat
+	}
+	if mode != rssModeCompat && mode != rssModeStrict {
+		return "", fmt.Errorf("unknown rss mode %q (use %q or %q)", site.RSSMode, rssModeCompat, rssModeStrict)
+	}
+	return mode, nil
+} // Ende rssMode.
+
+func writeXML(outputPath string, value any) error { // Schreibt ein XML-Dokument inkl. Header (RSS, Atom).
 	file, err := os.Create(outputPath) // Zieldatei erstellen/überschreiben.
 	if err != nil {                    // Wenn das nicht geht (Permission, Pfad)…
 		return err // …Fehler zurück.
@@ -400,14 +642,17 @@ func buildFeed(site Site, entries []Entry, outputPath string) error { // Baut fe
 
 	enc := xml.NewEncoder(file) // XML-Encoder, der direkt in die Datei schreibt.
 	enc.Indent("", "  ")        // Pretty Print: Einrückung für Lesbarkeit.
-	return enc.Encode(rss)      // RSS struct als XML schreiben; gibt ggf. error zurück.
-} // Ende buildFeed.
+	return enc.Encode(value)    // Struct als XML schreiben; gibt ggf. error zurück.
+} // Ende writeXML.
 
 func parseTime(value string) (time.Time, error) { // Erwartet RFC3339 timestamps (CreatedAt).
 	return time.Parse(time.RFC3339, strings.TrimSpace(value)) // Trimmt und parsed.
 } // Ende parseTime.
 
-func pickEntryID(provider string, item feed.Item) string { // Generiert ID stabil anhand PubDate/Link.
+func pickEntryID(provider string, item feed.Item) string { // Generiert ID stabil anhand GUID/PubDate/Link.
+	if guid := strings.TrimSpace(item.GUID); guid != "" { // Quelle liefert eine eigene Kennung (z.B. slug@version)…
+		return hashString(provider + "|guid:" + guid) // …dann zählt nur sie; Präfix trennt sie von PubDate-Basen.
+	}
 	base := strings.TrimSpace(item.PubDate) // Primär: PubDate als Basis (stabil bei Feeds).
 	if base == "" {                         // Wenn PubDate fehlt…
 		base = strings.TrimSpace(item.Link) // …nutze Link als Basis.
@@ -419,8 +664,8 @@ func pickEntryID(provider string, item feed.Item) string { // Generiert ID stabi
 } // Ende pickEntryID.
 
 func pickEntryTime(item feed.Item) string { // Ermittelt CreatedAt aus PubDate, fallback now.
-	parsed, err := parsePubDate(item.PubDate) // Versucht, PubDate in Time zu parsen.
-	if err != nil {                           // Wenn das nicht klappt…
+	parsed, err := feed.ParsePubDate(item.PubDate) // Versucht, PubDate in Time zu parsen.
+	if err != nil {                                // Wenn das nicht klappt…
 		return time.Now().UTC().Format(time.RFC3339) // …nutze "jetzt" (besser als leer).
 	} // Ende error-check.
 	return parsed.UTC().Format(time.RFC3339) // Normalisiert als RFC3339 String (UTC).
@@ -435,17 +680,6 @@ func idExists(entries []Entry, id string) bool { // Prüft, ob ID schon in entri
 	return false // Nicht gefunden.
 } // Ende idExists.
 
-func parsePubDate(value string) (time.Time, error) { // Parst PubDate aus RSS/HTTP-Feeds.
-	value = strings.TrimSpace(value) // Whitespace entfernen.
-	if value == "" {                 // Wenn leer…
-		return time.Time{}, fmt.Errorf("empty pubDate") // …Fehler, damit Caller fallbacken kann.
-	} // Ende empty-check.
-	if parsed, err := time.Parse(time.RFC1123Z, value); err == nil { // Erst RFC1123Z versuchen (mit Offset).
-		return parsed, nil // Erfolg.
-	} // Ende first try.
-	return time.Parse(time.RFC1123, value) // Sonst RFC1123 ohne explizites Offset versuchen.
-} // Ende parsePubDate.
-
 func hashString(value string) string { // Macht aus beliebigem Text einen stabilen Hex-Hash.
 	value = strings.TrimSpace(value) // Normalisieren: verhindert Hash-Varianten durch Whitespace.
 	if value == "" {                 // Falls wirklich leer…
@@ -464,18 +698,21 @@ func readJSON(path string, target any) { // Liest JSON-Datei in target, aber "si
 } // Ende readJSON.
 
 func writeJSON(path string, value any) { // Schreibt JSON-Datei, bei Fehlern hartes Exit.
+	if err := writeJSONFile(path, value); err != nil { // JSON schreiben.
+		fmt.Fprintln(os.Stderr, err) // Fehler ausgeben.
+		os.Exit(1)                   // Harte Beendigung (konsistenter State ist wichtig).
+	} // Ende error-check.
+} // Ende writeJSON.
+
+func writeJSONFile(path string, value any) error { // Schreibt JSON-Datei und gibt Fehler zurück (z.B. für Feed-Ausgaben).
 	file, err := os.Create(path) // Datei erstellen/überschreiben.
 	if err != nil {              // Wenn das fehlschlägt…
-		fmt.Fprintln(os.Stderr, err) // …Fehler ausgeben.
-		os.Exit(1)                   // …Programm beenden (weil Persistenz kritisch ist).
+		return err // …Fehler zurück.
 	} // Ende error-check.
 	defer file.Close() // Datei sicher schließen.
 
-	enc := json.NewEncoder(file)              // JSON Encoder auf Datei.
-	enc.SetIndent("", "  ")                   // Pretty JSON für bessere Diffbarkeit/Lesbarkeit.
-	enc.SetEscapeHTML(false)                  // Verhindert z.B. "<" zu "\u003c" (hilfreich für Content/Links).
-	if err := enc.Encode(value); err != nil { // JSON schreiben.
-		fmt.Fprintln(os.Stderr, err) // Fehler ausgeben.
-		os.Exit(1)                   // Harte Beendigung (konsistenter State ist wichtig).
-	} // Ende encode error-check.
-} // Ende writeJSON.
+	enc := json.NewEncoder(file) // JSON Encoder auf Datei.
+	enc.SetIndent("", "  ")      // Pretty JSON für bessere Diffbarkeit/Lesbarkeit.
+	enc.SetEscapeHTML(false)     // Verhindert z.B. "<" zu "\u003c" (hilfreich für Content/Links).
+	return enc.Encode(value)     // JSON schreiben; gibt ggf. error zurück.
+} // Ende writeJSONFile.
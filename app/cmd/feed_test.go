@@ -0,0 +1,20 @@
+package cmd
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	for title, want := range map[string]string{
+		"Make WordPress Core":    "make-wordpress-core",
+		"  WP Tavern!  ":         "wp-tavern",
+		"Post Status — Weekly":   "post-status-weekly",
+		"Grüße aus Köln":         "gr-e-aus-k-ln",
+		"!!!":                    "fallback",
+		"":                       "fallback",
+		"already-a-slug-2":       "already-a-slug-2",
+		"Trailing separators --": "trailing-separators",
+	} {
+		if got := slugify(title, "fallback"); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
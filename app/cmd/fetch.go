@@ -0,0 +1,188 @@
+package cmd // Paket "cmd": HTTP-Abruf der Quellen mit bedingten Requests (ETag/Last-Modified).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"context"        // Abbruch + Gesamt-Deadline für alle Requests eines Laufs.
+	"errors"         // errors.Is für feed.ErrNotModified.
+	"fmt"            // Fehlertexte mit Quelle + Status.
+	"io"             // io.Copy/io.Discard + io.ReadAll: Response-Body handhaben.
+	"net/http"       // HTTP-Client zum Abrufen der Feeds.
+	neturl "net/url" // Host für das Limit pro Host.
+	"os"             // Warnungen auf Stderr.
+	"strings"        // Host normalisieren.
+	"sync"           // Semaphore pro Host.
+	"time"           // Timeouts + Wartezeiten.
+
+	"wapuugotchi/feed/app/feed" // feed.ErrNotModified für 304-Antworten.
+)
+
+type fetcher struct { // Bündelt HTTP-Client, Retry-Policy und persistenten Cache für einen Lauf; sicher für parallele Provider.
+	client  *http.Client // Client mit Timeout; schützt vor Hängern.
+	policy  retryPolicy  // Backoff, Versuche und Gesamt-Deadline.
+	cache   *httpCache   // Validatoren + letzter Body pro URL; nil => ohne Cache.
+	verbose bool         // Jeden Versuch, Cache-Treffer und Fallbacks ausgeben.
+	perHost int          // Gleichzeitige Requests pro Host.
+
+	hostsMu sync.Mutex               // Schützt hosts.
+	hosts   map[string]chan struct{} // Semaphore pro Host.
+}
+
+func newFetcher(cacheDir string, config fetchConfig, verbose bool) *fetcher { // Erzeugt den Fetcher für einen Update-Lauf.
+	policy := newRetryPolicy(config, time.Now())
+	return &fetcher{
+		client:  &http.Client{Timeout: policy.timeout},
+		policy:  policy,
+		cache:   newHTTPCache(cacheDir),
+		verbose: verbose,
+		perHost: config.perHost(),
+		hosts:   make(map[string]chan struct{}),
+	}
+}
+
+func (f *fetcher) acquire(ctx context.Context, target string) (func(), error) { // Belegt einen Slot für den Host der URL; Rückgabe gibt ihn wieder frei.
+	host := target
+	if parsed, err := neturl.Parse(target); err == nil && parsed.Host != "" {
+		host = strings.ToLower(parsed.Host)
+	}
+	f.hostsMu.Lock()
+	slots, ok := f.hosts[host]
+	if !ok {
+		slots = make(chan struct{}, f.perHost)
+		f.hosts[host] = slots
+	}
+	f.hostsMu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done(): // Abbruch während wir auf einen freien Slot warten.
+		return nil, ctx.Err()
+	}
+}
+
+func (f *fetcher) fetch(ctx context.Context, url, source string) ([]byte, error) { // feed.Fetcher: bedingter GET mit Fallback auf den Cache.
+	cached, hasCache := f.cache.load(url) // Letzter bekannter Stand dieser URL (falls vorhanden).
+
+	body, resp, err := f.get(ctx, url, source, cached)
+	if err != nil { // Upstream nicht erreichbar oder Fehlerstatus…
+		if ctx.Err() != nil { // Lauf abgebrochen: kein Fallback, der Parser soll gar nicht erst weitermachen.
+			return nil, ctx.Err()
+		}
+		if hasCache && cached.Body != "" { // …dann lieber den letzten Body als gar nichts.
+			fmt.Fprintf(os.Stderr, "%s: %v (using cached copy from %s)\n", source, err, cached.FetchedAt)
+			return []byte(cached.Body), nil
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified { // Nichts geändert: kein Parsen, kein KI-Call.
+		if f.verbose {
+			fmt.Printf("%s: not modified\n", source)
+		}
+		return nil, feed.ErrNotModified
+	}
+
+	f.cache.stage(url, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"), body) // Validatoren + Body für den nächsten Lauf; gespeichert wird erst nach saveEntries.
+	return body, nil
+}
+
+func (f *fetcher) fetchCurrent(ctx context.Context, url, source string) ([]byte, error) { // Wie fetch, aber 304 liefert den gespeicherten Body.
+	body, err := f.fetch(ctx, url, source)
+	if errors.Is(err, feed.ErrNotModified) { // Unverändert heißt hier nicht "nichts zu tun": die Daten braucht jeder Lauf.
+		if cached, ok := f.cache.load(url); ok && cached.Body != "" {
+			return []byte(cached.Body), nil
+		}
+	}
+	if err == nil { // Daraus entstehen keine Entries: sofort speichern.
+		f.cache.commit([]string{url})
+	}
+	return body, err
+}
+
+func (f *fetcher) get(ctx context.Context, url, source string, cached httpCacheEntry) ([]byte, *http.Response, error) { // HTTP GET mit Retry-Policy.
+	ctx, cancel := context.WithDeadline(ctx, f.policy.deadline) // Gesamtbudget des Laufs gilt auch für laufende Requests.
+	defer cancel()
+
+	var lastErr error
+	for attempt := 0; attempt < f.policy.attempts; attempt++ {
+		release, err := f.acquire(ctx, url) // Nur der Request belegt den Host-Slot, nicht das Warten zwischen Versuchen.
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", source, err)
+		}
+		body, resp, err := f.attempt(ctx, url, cached)
+		release()
+		if err == nil && !retryableStatus(resp.StatusCode) {
+			if f.verbose {
+				fmt.Printf("%s: attempt %d/%d: %s\n", source, attempt+1, f.policy.attempts, resp.Status)
+			}
+			if resp.StatusCode == http.StatusNotModified || (resp.StatusCode >= 200 && resp.StatusCode < 300) {
+				return body, resp, nil
+			}
+			return nil, nil, fmt.Errorf("%s api status: %s", source, resp.Status) // 4xx o.ä.: Retry bringt nichts.
+		}
+
+		retryAfter, reason := "", ""
+		if err != nil {
+			if !retryableError(err) || ctx.Err() != nil { // DNS, kaputte URL, Deadline erreicht: sofort aufgeben.
+				return nil, nil, fmt.Errorf("%s: %w", source, err)
+			}
+			reason = err.Error()
+			lastErr = fmt.Errorf("%s: %w", source, err)
+		} else {
+			retryAfter = resp.Header.Get("Retry-After")
+			reason = resp.Status
+			lastErr = fmt.Errorf("%s api status: %s", source, resp.Status)
+		}
+		if attempt+1 >= f.policy.attempts {
+			break
+		}
+
+		delay := f.policy.wait(attempt, retryAfter, time.Now())
+		if time.Now().Add(delay).After(f.policy.deadline) { // Wartezeit sprengt das Budget: nicht mehr versuchen.
+			if f.verbose {
+				fmt.Printf("%s: attempt %d/%d: %s, giving up (run deadline)\n", source, attempt+1, f.policy.attempts, reason)
+			}
+			return nil, nil, fmt.Errorf("%w (run deadline reached)", lastErr)
+		}
+		if f.verbose {
+			fmt.Printf("%s: attempt %d/%d: %s, retrying in %s\n", source, attempt+1, f.policy.attempts, reason, delay.Round(time.Millisecond))
+		}
+		select { // Warten, aber bei Abbruch sofort aufhören.
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("%s: %w", source, ctx.Err())
+		}
+	}
+	return nil, nil, fmt.Errorf("%w (after %d attempts)", lastErr, f.policy.attempts)
+}
+
+func (f *fetcher) attempt(ctx context.Context, url string, cached httpCacheEntry) ([]byte, *http.Response, error) { // Ein einzelner Request.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil) // Request bauen.
+	if err != nil {                                                       // Wenn URL kaputt o.ä.
+		return nil, nil, err
+	}
+	req.Header.Set("User-Agent", userAgent) // Setzt User-Agent.
+	req.Header.Set("Accept", acceptHeader)  // Setzt Accept Header.
+	if cached.ETag != "" {                  // Bedingter Request: Server darf mit 304 antworten.
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+
+	resp, err := f.client.Do(req) // Request ausführen.
+	if err != nil {               // Netzwerkfehler, DNS, Timeout, etc.
+		return nil, nil, err
+	}
+	defer resp.Body.Close() // Immer schließen, sonst Leak.
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 { // 304/Fehlerstatus: Body wird nicht gebraucht.
+		_, _ = io.Copy(io.Discard, resp.Body) // Body leeren, damit Keep-Alive sauber ist.
+		return nil, resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body) // Body vollständig lesen; Abbruch mittendrin ist retrybar.
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, resp, nil
+}
@@ -0,0 +1,262 @@
+package cmd // Paket "cmd": Markdown-Artikel (articles/*.md) mit Front Matter im YAML- (---) oder TOML-Stil (+++).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"fmt"     // Fehlertexte mit Zeilennummer.
+	"os"      // Markdown-Artikel schreiben.
+	"strconv" // Strings in doppelten Quotes auflösen/quoten.
+	"strings" // Zeilen und Werte zerlegen.
+
+	"wapuugotchi/feed/app/feed" // feed.MarkdownToHTML für den Body.
+)
+
+var frontMatterKeys = []string{"title", "link", "iframe", "created_at", "categories", "id"} // Erlaubte Schlüssel; "categories" ist eine Liste.
+
+// parseMarkdownArticle zerlegt einen Markdown-Artikel in Front Matter und Body; der Body wird als HTML-Subset
+// gerendert. Unbekannte Schlüssel sind kein Fehler, werden aber zurückgegeben (validate meldet sie).
+func parseMarkdownArticle(data []byte) (Entry, []string, error) {
+	text := strings.TrimPrefix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\ufeff") // BOM von Windows-Editoren.
+	lines := strings.Split(text, "\n")
+	delimiter := strings.TrimSpace(lines[0])
+	if delimiter != "---" && delimiter != "+++" {
+		return Entry{}, nil, fmt.Errorf("missing front matter (start the file with --- or +++)")
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == delimiter {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return Entry{}, nil, fmt.Errorf("front matter is not closed (missing %s)", delimiter)
+	}
+
+	values, err := parseFrontMatter(lines[1:end], delimiter == "+++")
+	if err != nil {
+		return Entry{}, nil, err
+	}
+	var entry Entry
+	var unknown []string
+	for _, value := range values {
+		switch value.key {
+		case "title":
+			err = value.scalar(&entry.Title)
+		case "link":
+			err = value.scalar(&entry.Link)
+		case "iframe":
+			err = value.scalar(&entry.Iframe)
+		case "created_at":
+			err = value.scalar(&entry.CreatedAt)
+		case "id":
+			err = value.scalar(&entry.ID)
+		case "categories":
+			entry.Categories = value.list()
+		default:
+			unknown = append(unknown, value.key)
+		}
+		if err != nil {
+			return Entry{}, nil, err
+		}
+	}
+	entry.Content = feed.MarkdownToHTML(strings.Join(lines[end+1:], "\n"))
+	return entry, unknown, nil
+}
+
+type frontMatterValue struct { // Ein Schlüssel mit Skalar oder Liste.
+	key    string
+	line   int // Zeile in der Datei (für Fehlermeldungen).
+	values []string
+	isList bool
+}
+
+func (v frontMatterValue) scalar(target *string) error {
+	if v.isList {
+		return fmt.Errorf("line %d: %s must be a single value, not a list", v.line, v.key)
+	}
+	if len(v.values) > 0 {
+		*target = v.values[0]
+	}
+	return nil
+}
+
+func (v frontMatterValue) list() []string { // "categories: News" zählt als Liste mit einem Eintrag.
+	return cleanCategories(v.values)
+}
+
+func parseFrontMatter(lines []string, toml bool) ([]frontMatterValue, error) { // key: value (YAML) bzw. key = value (TOML).
+	separator := ":"
+	if toml {
+		separator = "="
+	}
+	var values []frontMatterValue
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lineNumber := i + 2                         // +1 für die Trennzeile, +1 weil Zeilen ab 1 zählen.
+		if !toml && strings.HasPrefix(line, "- ") { // YAML-Listenpunkt ohne vorherigen "key:".
+			if len(values) == 0 || !values[len(values)-1].isList {
+				return nil, fmt.Errorf("line %d: list item without a key", lineNumber)
+			}
+			item, err := frontMatterScalar(strings.TrimSpace(line[2:]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			values[len(values)-1].values = append(values[len(values)-1].values, item)
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, separator)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key%svalue", lineNumber, separator)
+		}
+		value := frontMatterValue{key: strings.ToLower(strings.TrimSpace(key)), line: lineNumber}
+		raw = strings.TrimSpace(raw)
+		switch {
+		case raw == "" && !toml: // YAML-Liste folgt in den nächsten Zeilen ("- a").
+			value.isList = true
+		case strings.HasPrefix(raw, "["):
+			raw = stripComment(raw)
+			for !strings.Contains(raw, "]") && i+1 < len(lines) { // TOML erlaubt mehrzeilige Arrays; Kommentare pro Zeile entfernen.
+				i++
+				if next := strings.TrimSpace(lines[i]); !strings.HasPrefix(next, "#") {
+					raw += " " + stripComment(next)
+				}
+			}
+			items, err := frontMatterList(raw)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			value.values, value.isList = items, true
+		default:
+			item, err := frontMatterScalar(raw)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			value.values = []string{item}
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func frontMatterList(raw string) ([]string, error) { // [a, "b, c", 'd'] → Einträge; Kommas in Quotes bleiben erhalten.
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(raw, "[") || !strings.HasSuffix(raw, "]") {
+		return nil, fmt.Errorf("list must be written as [a, b]")
+	}
+	raw = raw[1 : len(raw)-1]
+	var items []string
+	var current strings.Builder
+	quote := rune(0)
+	escaped := false
+	flush := func() error {
+		item, err := frontMatterScalar(strings.TrimSpace(current.String()))
+		current.Reset()
+		if err != nil {
+			return err
+		}
+		if item != "" {
+			items = append(items, item)
+		}
+		return nil
+	}
+	for _, r := range raw {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == ',':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in list")
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func frontMatterScalar(raw string) (string, error) { // "text", 'text' oder text (ohne Kommentar am Ende).
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		end := closingQuote(raw)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string %s", raw)
+		}
+		return strconv.Unquote(raw[:end+1])
+	case strings.HasPrefix(raw, "'"): // Einfache Quotes: '' steht für ein einzelnes '.
+		body := raw[1:]
+		var out strings.Builder
+		for i := 0; i < len(body); i++ {
+			if body[i] != '\'' {
+				out.WriteByte(body[i])
+				continue
+			}
+			if i+1 < len(body) && body[i+1] == '\'' {
+				out.WriteByte('\'')
+				i++
+				continue
+			}
+			return out.String(), nil
+		}
+		return "", fmt.Errorf("unterminated string %s", raw)
+	default:
+		return strings.TrimSpace(stripComment(raw)), nil
+	}
+}
+
+func closingQuote(raw string) int { // Index des schließenden " (Escapes überspringen); -1, wenn keins.
+	for i := 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func stripComment(raw string) string { // "wert # Kommentar" → "wert"; "#" ohne Leerzeichen davor gehört zum Wert (URLs mit Anker).
+	if index := strings.Index(raw, " #"); index >= 0 {
+		return raw[:index]
+	}
+	return raw
+}
+
+func writeMarkdownArticle(path string, entry Entry) error { // Gegenstück zu parseMarkdownArticle (für add-article --markdown).
+	var out strings.Builder
+	out.WriteString("---\n")
+	field := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(&out, "%s: %s\n", key, strconv.Quote(value))
+		}
+	}
+	field("id", entry.ID)
+	field("title", entry.Title)
+	field("link", entry.Link)
+	field("iframe", entry.Iframe)
+	field("created_at", entry.CreatedAt)
+	if len(entry.Categories) > 0 {
+		quoted := make([]string, len(entry.Categories))
+		for i, category := range entry.Categories {
+			quoted[i] = strconv.Quote(category)
+		}
+		fmt.Fprintf(&out, "categories: [%s]\n", strings.Join(quoted, ", "))
+	}
+	out.WriteString("---\n\n" + entry.Content + "\n")
+	return os.WriteFile(path, []byte(out.String()), 0644)
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMarkdownArticle(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        Entry
+		wantUnknown []string
+	}{
+		{
+			name: "yaml with list items",
+			input: "---\n" +
+				"title: \"Hello: World\"\n" +
+				"link: https://example.org/post#top # Kommentar\n" +
+				"created_at: 2026-04-11T09:00:00Z\n" +
+				"categories:\n" +
+				"  - News\n" +
+				"  - 'It''s new'\n" +
+				"---\n\nSome **bold** text.\n",
+			want: Entry{
+				Title:      "Hello: World",
+				Link:       "https://example.org/post#top",
+				CreatedAt:  "2026-04-11T09:00:00Z",
+				Categories: []string{"News", "It's new"},
+				Content:    "<p>Some <strong>bold</strong> text.</p>",
+			},
+		},
+		{
+			name:  "yaml inline list and single category",
+			input: "---\ntitle: Plain\ncategories: [a, \"b, c\"]\n---\nbody",
+			want:  Entry{Title: "Plain", Categories: []string{"a", "b, c"}, Content: "<p>body</p>"},
+		},
+		{
+			name: "toml with multi-line array",
+			input: "+++\n" +
+				"title = \"TOML \\\"quoted\\\"\"\n" +
+				"id = 'abc123def'\n" +
+				"categories = [\n" +
+				"  \"News\",\n" +
+				"  \"Release\", # Kommentar\n" +
+				"]\n" +
+				"+++\n- one\n- two\n",
+			want: Entry{ID: "abc123def", Title: `TOML "quoted"`, Categories: []string{"News", "Release"}, Content: "<ul><li>one</li><li>two</li></ul>"},
+		},
+		{
+			name:        "unknown keys are reported",
+			input:       "\ufeff---\r\ntitle: BOM\r\nAuthor: me\r\ndraft: true\r\n---\r\ntext",
+			want:        Entry{Title: "BOM", Content: "<p>text</p>"},
+			wantUnknown: []string{"author", "draft"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unknown, err := parseMarkdownArticle([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("parseMarkdownArticle: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("entry = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(unknown, tt.wantUnknown) {
+				t.Errorf("unknown = %q, want %q", unknown, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestParseMarkdownArticleErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "missing front matter", input: "# Title\n\ntext", wantErr: "missing front matter"},
+		{name: "not closed", input: "---\ntitle: x\n", wantErr: "not closed (missing ---)"},
+		{name: "mixed delimiters", input: "+++\ntitle = \"x\"\n---\n", wantErr: "not closed (missing +++)"},
+		{name: "list for scalar", input: "---\ntitle: [a, b]\n---\n", wantErr: "line 2: title must be a single value"},
+		{name: "list item without key", input: "---\n- a\n---\n", wantErr: "line 2: list item without a key"},
+		{name: "missing separator", input: "+++\ntitle: x\n+++\n", wantErr: "line 2: expected key=value"},
+		{name: "unterminated string", input: "---\ntitle: \"open\n---\n", wantErr: "line 2: unterminated string"},
+		{name: "unterminated quote in list", input: "---\ncategories: [\"a, b]\n---\n", wantErr: "line 2: unterminated quote"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseMarkdownArticle([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("parseMarkdownArticle error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteMarkdownArticleRoundTrip(t *testing.T) { // add-article --markdown muss lesen können, was es schreibt.
+	entry := Entry{
+		ID:         "0123456789abcdef",
+		Title:      `Quotes "and" colons: fine`,
+		Link:       "https://example.org/a?b=1#c",
+		CreatedAt:  "2026-04-11T09:00:00Z",
+		Categories: []string{"News", "a, b"},
+		Content:    "Hello *world*",
+	}
+	path := filepath.Join(t.TempDir(), "article.md")
+	if err := writeMarkdownArticle(path, entry); err != nil {
+		t.Fatalf("writeMarkdownArticle: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, unknown, err := parseMarkdownArticle(data)
+	if err != nil {
+		t.Fatalf("parseMarkdownArticle: %v", err)
+	}
+	entry.Content = "<p>Hello <em>world</em></p>"
+	if !reflect.DeepEqual(got, entry) || len(unknown) > 0 {
+		t.Errorf("round trip = %+v (unknown %q), want %+v", got, unknown, entry)
+	}
+}
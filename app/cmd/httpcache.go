@@ -0,0 +1,89 @@
+package cmd // Paket "cmd": persistenter HTTP-Cache (ETag, Last-Modified, letzter Body) pro URL.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"crypto/sha256" // Dateiname aus der URL ableiten.
+	"encoding/hex"  // Hash als Hex-String.
+	"os"            // Verzeichnis anlegen.
+	"path/filepath" // Pfade im Cache-Verzeichnis.
+	"sync"          // Schutz bei parallelen Zugriffen.
+	"time"          // Zeitpunkt des letzten Abrufs.
+)
+
+type httpCacheEntry struct { // Ein gecachter Abruf, gespeichert als .cache/http/<sha256(url)>.json.
+	URL          string `json:"url"`                     // Ursprüngliche URL (zur Kontrolle/Diffbarkeit).
+	ETag         string `json:"etag,omitempty"`          // Für If-None-Match.
+	LastModified string `json:"last_modified,omitempty"` // Für If-Modified-Since.
+	FetchedAt    string `json:"fetched_at"`              // Zeitpunkt des letzten erfolgreichen Abrufs (RFC3339).
+	Body         string `json:"body"`                    // Letzter Body; Fallback, wenn Upstream ausfällt.
+}
+
+type httpCache struct { // Cache-Verzeichnis; ein File pro URL hält Diffs klein.
+	dir     string
+	mu      sync.Mutex
+	pending map[string]httpCacheEntry // Abrufe dieses Laufs; erst commit schreibt sie auf Platte.
+}
+
+func newHTTPCache(dir string) *httpCache { // Leeres dir => Cache deaktiviert (nil).
+	if dir == "" {
+		return nil
+	}
+	return &httpCache{dir: dir, pending: make(map[string]httpCacheEntry)}
+}
+
+func (c *httpCache) path(url string) string { // Dateiname: SHA-256 der URL.
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *httpCache) load(url string) (httpCacheEntry, bool) { // Lädt den Eintrag einer URL; false, wenn keiner existiert.
+	if c == nil {
+		return httpCacheEntry{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var entry httpCacheEntry
+	readJSON(c.path(url), &entry) // Fehlende/kaputte Datei => leerer Eintrag.
+	if entry.URL != url {         // Schutz gegen kaputte Dateien (oder Hash-Kollision).
+		return httpCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *httpCache) stage(url, etag, lastModified string, body []byte) { // Merkt Validatoren + Body vor; auf Platte erst mit commit.
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending[url] = httpCacheEntry{
+		URL:          url,
+		ETag:         etag,
+		LastModified: lastModified,
+		FetchedAt:    time.Now().UTC().Format(time.RFC3339),
+		Body:         string(body),
+	}
+}
+
+// commit schreibt die vorgemerkten Einträge der URLs. Erst aufrufen, wenn die Items daraus gespeichert sind:
+// sonst antwortet der nächste Lauf mit 304 und die Items gehen verloren. Fehler sind nicht fatal.
+func (c *httpCache) commit(urls []string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, url := range urls {
+		entry, ok := c.pending[url]
+		if !ok { // 304, Cache-Fallback oder schon geschrieben.
+			continue
+		}
+		delete(c.pending, url)
+		if err := os.MkdirAll(c.dir, 0o755); err != nil {
+			return
+		}
+		_ = writeJSONFile(c.path(url), entry)
+	}
+}
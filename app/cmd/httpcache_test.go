@@ -0,0 +1,36 @@
+package cmd
+
+import "testing"
+
+func TestHTTPCacheCommitsOnlyAfterCommit(t *testing.T) {
+	cache := newHTTPCache(t.TempDir())
+	const url = "https://example.org/feed/"
+
+	cache.stage(url, `"v1"`, "Mon, 06 Apr 2026 08:00:00 GMT", []byte("<rss/>"))
+	if _, ok := cache.load(url); ok {
+		t.Fatal("load after stage: entry is already on disk, a failed run would get a 304 next time")
+	}
+
+	cache.commit([]string{"https://example.org/other/", url})
+	entry, ok := cache.load(url)
+	if !ok {
+		t.Fatal("load after commit: entry missing")
+	}
+	if entry.ETag != `"v1"` || entry.LastModified == "" || entry.Body != "<rss/>" {
+		t.Errorf("entry = %+v, want validators and body", entry)
+	}
+
+	cache.commit([]string{url}) // Zweiter commit ohne neuen stage darf nichts überschreiben.
+	if again, _ := cache.load(url); again != entry {
+		t.Errorf("entry after second commit = %+v, want %+v", again, entry)
+	}
+}
+
+func TestHTTPCacheDisabled(t *testing.T) {
+	cache := newHTTPCache("") // Ohne Verzeichnis: alle Methoden sind No-ops.
+	cache.stage("https://example.org/", "", "", nil)
+	cache.commit([]string{"https://example.org/"})
+	if _, ok := cache.load("https://example.org/"); ok {
+		t.Error("disabled cache returned an entry")
+	}
+}
@@ -0,0 +1,94 @@
+package cmd // Paket "cmd": JSON-Feed-1.1-Ausgabe (feed.json) für das Wapuugotchi-Plugin.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"strings" // Trimmen von Links/Iframes.
+	"time"    // RFC3339-Zeitstempel für date_published.
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1" // Pflichtfeld "version" laut JSON Feed 1.1.
+
+type JSONFeed struct { // Root-Objekt für JSON Feed 1.1.
+	Version     string         `json:"version"`                 // Spezifikations-URL.
+	Title       string         `json:"title"`                   // Feed-Titel.
+	HomePageURL string         `json:"home_page_url,omitempty"` // Website des Feeds.
+	FeedURL     string         `json:"feed_url,omitempty"`      // URL dieser feed.json.
+	Description string         `json:"description,omitempty"`   // Feed-Beschreibung.
+	Authors     []JSONAuthor   `json:"authors,omitempty"`       // Feed-Autor(en).
+	Items       []JSONFeedItem `json:"items"`                   // Pflicht: Liste der Items (auch wenn leer).
+}
+
+type JSONAuthor struct { // Autor-Objekt aus JSON Feed 1.1.
+	Name string `json:"name"`
+}
+
+type JSONFeedItem struct { // JSON Feed Item: einzelne Nachricht.
+	ID            string           `json:"id"`                     // Entry.ID (Pflichtfeld).
+	URL           string           `json:"url,omitempty"`          // Link zum Original.
+	Title         string           `json:"title,omitempty"`        // Titel.
+	Authors       []JSONAuthor     `json:"authors,omitempty"`      // Autor aus der Quelle (optional).
+	ContentHTML   string           `json:"content_html,omitempty"` // Voller HTML-Content, unescaped.
+	DatePublished string           `json:"date_published"`         // RFC3339 aus CreatedAt.
+	Tags          []string         `json:"tags,omitempty"`         // Kategorien.
+	Attachments   []JSONAttachment `json:"attachments,omitempty"`  // Angehängte Dateien (Enclosures).
+	Wapuugotchi   WapuugotchiExt   `json:"_wapuugotchi"`           // Erweiterung (Unterstrich-Präfix laut Spezifikation).
+}
+
+type JSONAttachment struct { // Attachment-Objekt aus JSON Feed 1.1; mime_type ist Pflicht.
+	URL         string `json:"url"`
+	MimeType    string `json:"mime_type"`
+	SizeInBytes int64  `json:"size_in_bytes,omitempty"`
+}
+
+type WapuugotchiExt struct { // Plugin-spezifische Felder, die es im RSS nur als eigene XML-Elemente gibt.
+	Source  string   `json:"source,omitempty"`  // Quelle des Entries (Provider-Name oder "article").
+	Iframe  string   `json:"iframe,omitempty"`  // Optionales Embed (z.B. YouTube).
+	Release *Release `json:"release,omitempty"` // Release-Daten (Version, Kanal, Sicherheit, aktuell).
+}
+
+func writeJSONFeed(site Site, entries []Entry, outputPath string) error { // Baut feed.json aus Site + absteigend sortierten Entries.
+	feed := JSONFeed{
+		Version:     jsonFeedVersion,
+		Title:       site.Title,
+		HomePageURL: strings.TrimSpace(site.Link),
+		Description: site.Description,
+		Items:       []JSONFeedItem{}, // Leerer Slice statt null, damit "items" immer ein Array ist.
+	}
+	if title := strings.TrimSpace(site.Title); title != "" { // Autor nur mit Namen (leere Objekte sind ungültig).
+		feed.Authors = []JSONAuthor{{Name: title}}
+	}
+	if feed.HomePageURL != "" {
+		feed.FeedURL = resolveLink(feed.HomePageURL, jsonFeedFile)
+	}
+
+	for _, entry := range entries {
+		createdAt, err := parseTime(entry.CreatedAt)
+		if err != nil { // Wie bei RSS/Atom: kaputte Zeitstempel überspringen.
+			continue
+		}
+		var attachments []JSONAttachment
+		for _, enclosure := range entry.Enclosures {
+			attachments = append(attachments, JSONAttachment{URL: enclosure.URL, MimeType: enclosureType(enclosure), SizeInBytes: enclosure.Length})
+		}
+		var authors []JSONAuthor
+		if author := strings.TrimSpace(entry.Author); author != "" {
+			authors = []JSONAuthor{{Name: author}}
+		}
+		feed.Items = append(feed.Items, JSONFeedItem{
+			ID:            entry.ID,
+			URL:           strings.TrimSpace(entry.Link),
+			Title:         entry.Title,
+			Authors:       authors,
+			ContentHTML:   entry.Content,
+			DatePublished: createdAt.UTC().Format(time.RFC3339),
+			Tags:          entry.Categories,
+			Attachments:   attachments,
+			Wapuugotchi: WapuugotchiExt{
+				Source:  entry.Source,
+				Iframe:  strings.TrimSpace(entry.Iframe),
+				Release: entry.Release,
+			},
+		})
+	}
+
+	return writeJSONFile(outputPath, feed)
+}
@@ -1,49 +1,221 @@
-package cmd
+package cmd // Paket "cmd": Entries auflisten (Tabelle, JSON, CSV) und einzeln anzeigen.
 
-import (
-	"fmt"
-	"os"
-	"encoding/xml"
-	"io"
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/csv"   // Ausgabeformat csv.
+	"encoding/json"  // Ausgabeformat json.
+	"fmt"            // Tabellenzeilen, Fehlertexte.
+	"io"             // Ausgabe-Writer (Stdout, Tests).
+	"os"             // Stdout.
+	"sort"           // Sortierung nach Datum/Titel/Quelle.
+	"strings"        // Filter, Kategorien zusammenfügen.
+	"text/tabwriter" // Ausgerichtete Tabellenspalten.
+	"time"           // Zeitfilter since/until.
+	"unicode/utf8"   // Kürzen nach Zeichen statt Bytes.
 )
 
-func RunListItems()  {
-	fmt.Printf("Wapuugotchi Feed Generator\n")
-	feedFile := "feed.xml"
-	file, err := os.Open(feedFile)
-	if err != nil {
-		fmt.Printf("Error opening feed file: %v\n", err)
-		return
+const ( // Ausgabeformate von "list".
+	listTable = "table"
+	listJSON  = "json"
+	listCSV   = "csv"
+)
+
+const ( // Sortierschlüssel von "list".
+	sortDate   = "date"
+	sortTitle  = "title"
+	sortSource = "source"
+)
+
+const maxListCategories = 40 // Zeichen der Kategorie-Spalte in der Tabelle; JSON/CSV enthalten alle.
+
+type ListOptions struct { // Filter, Sortierung und Format für RunListItems (aus CLI-Flags).
+	Sources    []string  // Nur diese Quellen (case-insensitive); leer => alle.
+	Categories []string  // Nur Entries mit mindestens einer dieser Kategorien; leer => alle.
+	Since      time.Time // Nur Entries ab diesem Zeitpunkt (inklusive); Zero => keine Grenze.
+	Until      time.Time // Nur Entries vor diesem Zeitpunkt (exklusive); Zero => keine Grenze.
+	Search     string    // Teilstring in Titel oder Inhalt (case-insensitive).
+	Sort       string    // date (Default, neueste zuerst), title oder source.
+	Reverse    bool      // Sortierung umdrehen.
+	Format     string    // table (Default), json oder csv.
+}
+
+type listRow struct { // Eine Zeile für JSON/CSV: alles, was ein Skript zum Weiterverarbeiten braucht.
+	ID         string   `json:"id"`         // Volle ID (list-Tabelle zeigt nur die Kurz-ID).
+	Source     string   `json:"source"`     // Provider-Name oder "article".
+	CreatedAt  string   `json:"created_at"` // RFC3339 wie in entries.json.
+	Categories []string `json:"categories"` // Nie null, damit Skripte immer ein Array bekommen.
+	Title      string   `json:"title"`      // Titel.
+	Link       string   `json:"link"`       // Link zum Original.
+}
+
+// RunListItems listet die Entries, die in den Feeds stehen (entries.json + Artikel, ohne gelöschte).
+func RunListItems(root string, opts ListOptions) error {
+	paths, err := getPaths(root) // Dateipfade unter root.
+	if err != nil {              // Root fehlt/kein Verzeichnis.
+		return err
 	}
-	defer file.Close()
+	entries := filterEntries(liveEntries(paths), opts) // Gleiche Entries wie in den Feeds, dann Filter.
+	sortEntries(entries, opts.Sort, opts.Reverse)      // Reihenfolge nach --sort/--reverse.
 
-	decoder := xml.NewDecoder(file)
-	itemCount := 0
+	switch opts.Format { // Ausgabe nach --format.
+	case listJSON: // Für Skripte.
+		return writeListJSON(os.Stdout, entries)
+	case listCSV: // Für Tabellenkalkulationen.
+		return writeListCSV(os.Stdout, entries)
+	case "", listTable: // Default: lesbare Tabelle.
+		return writeListTable(os.Stdout, entries)
+	default: // Tippfehler melden statt still die Tabelle zu zeigen.
+		return fmt.Errorf("unknown list format %q (use %s, %s or %s)", opts.Format, listTable, listJSON, listCSV)
+	}
+}
 
-	for {
-		token, err := decoder.Token()
-		if err == io.EOF {
-			break
+func filterEntries(entries []Entry, opts ListOptions) []Entry { // Alle Filter müssen passen.
+	search := strings.ToLower(strings.TrimSpace(opts.Search)) // Einmal normalisieren statt pro Entry.
+	result := make([]Entry, 0, len(entries))                  // Höchstens so viele wie vorher.
+	for _, entry := range entries {
+		if len(opts.Sources) > 0 && !containsFold(opts.Sources, entry.Source) { // Andere Quelle.
+			continue
 		}
-		if err != nil {
-			fmt.Printf("Error parsing XML: %v\n", err)
-			return
+		if len(opts.Categories) > 0 && !anyCategory(entry.Categories, opts.Categories) { // Keine der gewünschten Kategorien.
+			continue
 		}
-		
-		if se, ok := token.(xml.StartElement); ok && se.Name.Local == "item" {
-			itemCount++
-		// Skip to end of item element to get title
-		var item struct {
-			Title string `xml:"title"`
+		if !opts.Since.IsZero() || !opts.Until.IsZero() { // Zeitfenster nur prüfen, wenn eins gesetzt ist.
+			created, err := parseTime(entry.CreatedAt)
+			if err != nil { // Ohne lesbares Datum passt der Entry in kein Zeitfenster.
+				continue
+			}
+			if !opts.Since.IsZero() && created.Before(opts.Since) { // since ist inklusive.
+				continue
+			}
+			if !opts.Until.IsZero() && !created.Before(opts.Until) { // until ist exklusive.
+				continue
+			}
 		}
-		err := decoder.DecodeElement(&item, &se)
-		if err != nil {
-			fmt.Printf("Error decoding item: %v\n", err)
+		if search != "" && !strings.Contains(strings.ToLower(entry.Title), search) && !strings.Contains(strings.ToLower(entry.Content), search) { // Weder im Titel noch im Inhalt.
 			continue
 		}
-		fmt.Printf("%d) Title: %s\n", itemCount, item.Title)
+		result = append(result, entry) // Alle Filter bestanden.
+	}
+	return result
+}
+
+func anyCategory(categories, wanted []string) bool { // Mindestens eine gewünschte Kategorie vorhanden?
+	for _, category := range wanted {
+		if containsFold(categories, category) { // Groß-/Kleinschreibung egal.
+			return true
+		}
+	}
+	return false // Keine gemeinsame Kategorie.
+}
+
+func sortEntries(entries []Entry, by string, reverse bool) { // Stabil, damit gleiche Schlüssel nach Datum geordnet bleiben.
+	less := func(a, b Entry) bool { return a.CreatedAt > b.CreatedAt } // Default: neueste zuerst.
+	switch by {
+	case sortTitle: // Alphabetisch, Groß-/Kleinschreibung egal.
+		less = func(a, b Entry) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	case sortSource: // Nach Provider-Name gruppiert.
+		less = func(a, b Entry) bool { return a.Source < b.Source }
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if reverse { // Argumente tauschen statt Ergebnis negieren: gleiche Schlüssel bleiben stabil.
+			return less(entries[j], entries[i])
 		}
+		return less(entries[i], entries[j])
+	})
+}
+
+func writeListTable(w io.Writer, entries []Entry) error { // Menschenlesbare Tabelle mit Kurz-IDs.
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)           // Spalten mit zwei Leerzeichen Abstand.
+	fmt.Fprintln(table, "ID\tSOURCE\tDATE\tCATEGORIES\tTITLE") // Kopfzeile.
+	for _, entry := range entries {                            // Eine Zeile pro Entry; Kategorien gekürzt.
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", shortID(entry.ID), entry.Source, entryDate(entry), truncate(strings.Join(entry.Categories, ","), maxListCategories), entry.Title)
 	}
+	if err := table.Flush(); err != nil { // tabwriter puffert bis hier.
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Total items: %d\n", len(entries)) // Summenzeile wie in der alten list-Ausgabe.
+	return err
+}
 
-	fmt.Printf("Total items: %d\n", itemCount)
-}
\ No newline at end of file
+func writeListJSON(w io.Writer, entries []Entry) error { // JSON-Array mit vollen IDs.
+	rows := make([]listRow, 0, len(entries)) // Leere Liste => "[]", nicht "null".
+	for _, entry := range entries {
+		rows = append(rows, newListRow(entry))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")  // Lesbar, auch in der Konsole.
+	enc.SetEscapeHTML(false) // "&" in Titeln nicht als \u0026 ausgeben.
+	return enc.Encode(rows)
+}
+
+func writeListCSV(w io.Writer, entries []Entry) error { // CSV mit Kopfzeile; Quoting übernimmt encoding/csv.
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"id", "source", "created_at", "categories", "title", "link"}); err != nil { // Kopfzeile.
+		return err
+	}
+	for _, entry := range entries {
+		row := newListRow(entry)                                                                                                               // Gleiche Felder wie JSON.
+		if err := out.Write([]string{row.ID, row.Source, row.CreatedAt, strings.Join(row.Categories, ";"), row.Title, row.Link}); err != nil { // Kategorien mit ";", weil "," das Trennzeichen ist.
+			return err
+		}
+	}
+	out.Flush()        // Gepufferte Zeilen schreiben.
+	return out.Error() // Schreibfehler kommen erst nach Flush.
+}
+
+func newListRow(entry Entry) listRow { // Entry → Zeile für JSON/CSV.
+	categories := entry.Categories
+	if categories == nil { // JSON: [] statt null.
+		categories = []string{}
+	}
+	return listRow{
+		ID:         entry.ID,
+		Source:     entry.Source,
+		CreatedAt:  entry.CreatedAt,
+		Categories: categories,
+		Title:      entry.Title,
+		Link:       entry.Link,
+	}
+}
+
+func truncate(value string, max int) string { // Kürzt auf max Zeichen (Runes) mit "…".
+	if utf8.RuneCountInString(value) <= max { // Passt schon.
+		return value
+	}
+	return string([]rune(value)[:max-1]) + "…" // Platz für das Auslassungszeichen lassen.
+}
+
+// RunShowItem zeigt einen Entry per ID oder eindeutigem ID-Präfix mit allen Feldern und dem Inhalt.
+func RunShowItem(root, id string) error {
+	paths, err := getPaths(root) // Dateipfade unter root.
+	if err != nil {
+		return err
+	}
+	entry, err := findEntry(liveEntries(paths), id) // Gleiche Auflösung wie delete.
+	if err != nil {                                 // Unbekannt, zu kurz oder mehrdeutig.
+		return err
+	}
+
+	fmt.Printf("ID:         %s\n", entry.ID) // Volle ID, z.B. zum Kopieren für delete.
+	fmt.Printf("Title:      %s\n", entry.Title)
+	fmt.Printf("Link:       %s\n", entry.Link)
+	fmt.Printf("Source:     %s\n", entry.Source)
+	fmt.Printf("Published:  %s\n", entry.CreatedAt)
+	if entry.Author != "" { // Optionale Felder nur, wenn gesetzt.
+		fmt.Printf("Author:     %s\n", entry.Author)
+	}
+	if len(entry.Categories) > 0 {
+		fmt.Printf("Categories: %s\n", strings.Join(entry.Categories, ", "))
+	}
+	if entry.Release != nil {
+		fmt.Printf("Release:    %s (%s)\n", entry.Release.Version, entry.Release.Channel)
+	}
+	fmt.Printf("\n%s\n", entry.Content) // Inhalt als HTML nach einer Leerzeile.
+	return nil
+}
+
+func entryDate(entry Entry) string { // Nur das Datum (YYYY-MM-DD) aus CreatedAt.
+	if parsed, err := parseTime(entry.CreatedAt); err == nil {
+		return parsed.UTC().Format("2006-01-02") // In UTC, wie die Feeds.
+	}
+	return entry.CreatedAt // Unlesbar: unverändert zeigen statt verschlucken.
+}
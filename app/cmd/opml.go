@@ -0,0 +1,198 @@
+package cmd // Paket "cmd": OPML-Import/-Export der Provider (Abo-Listen aus anderen Feed-Readern).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/xml" // OPML lesen/schreiben.
+	"fmt"          // Zusammenfassung + Fehlertexte.
+	"io"           // Export nach Stdout oder Datei.
+	"os"           // Dateien lesen/schreiben.
+	"strconv"      // Namenssuffixe bei Kollisionen.
+	"strings"      // Namen/URLs normalisieren.
+
+	"wapuugotchi/feed/app/feed" // feed.KindFeed, feed.FeedURL, feed.LoadPrompts.
+)
+
+const importKeep = 10 // Retention für importierte Quellen (wie bei den mitgelieferten Beispiel-Quellen).
+
+type OPML struct { // Root-Objekt von OPML 2.0.
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    OPMLHead `xml:"head"`
+	Body    OPMLBody `xml:"body"`
+}
+
+type OPMLHead struct { // <head>: nur der Titel, damit der Export ohne Zeitstempel stabil diffbar bleibt.
+	Title string `xml:"title"`
+}
+
+type OPMLBody struct { // <body> mit beliebig verschachtelten <outline>-Elementen.
+	Outlines []OPMLOutline `xml:"outline"`
+}
+
+type OPMLOutline struct { // Ein Abo (mit xmlUrl) oder ein Ordner (mit Kind-Outlines).
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`     // "rss" für Feeds.
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`   // Feed-URL.
+	HTMLURL  string        `xml:"htmlUrl,attr,omitempty"`  // Website (optional).
+	Category string        `xml:"category,attr,omitempty"` // Kommagetrennte Kategorien.
+	Outlines []OPMLOutline `xml:"outline"`
+}
+
+// RunImportOPML übernimmt die Feeds einer OPML-Datei als Provider der Art "rss" in data/providers.json.
+// Feeds, deren URL schon konfiguriert ist, werden übersprungen; Ordnernamen werden zu Kategorien.
+func RunImportOPML(root, path string) error {
+	paths, err := getPaths(root)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var doc OPML
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	config, err := loadProvidersFile(paths.providers)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]struct{}, len(config.Providers))
+	urls := make(map[string]struct{}, len(config.Providers))
+	for _, provider := range config.Providers {
+		names[provider.name()] = struct{}{}
+		if url, ok := feed.FeedURL(provider.Kind, provider.URL); ok {
+			urls[normalizeFeedURL(url)] = struct{}{}
+		}
+	}
+
+	imported, skipped := 0, 0
+	for _, outline := range flattenOutlines(doc.Body.Outlines, nil) {
+		key := normalizeFeedURL(outline.XMLURL)
+		if _, exists := urls[key]; exists {
+			skipped++
+			continue
+		}
+		urls[key] = struct{}{}
+		name := uniqueName(slugify(outlineTitle(outline), "feed"), names)
+		names[name] = struct{}{}
+		config.Providers = append(config.Providers, providerConfig{
+			Name:       name,
+			Kind:       feed.KindFeed,
+			URL:        strings.TrimSpace(outline.XMLURL),
+			Enabled:    true,
+			Categories: cleanCategories(strings.Split(outline.Category, ",")),
+			Retention:  &retentionConfig{Keep: importKeep},
+		})
+		imported++
+	}
+	if imported > 0 {
+		prompts, err := feed.LoadPrompts(paths.prompts)
+		if err != nil {
+			return err
+		}
+		if _, err := providers(config.Providers, prompts); err != nil { // Nichts schreiben, was der nächste Lauf ablehnen würde.
+			return err
+		}
+		if err := writeJSONFile(paths.providers, config); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("imported %d providers, skipped %d already configured\n", imported, skipped)
+	return nil
+}
+
+// RunExportOPML schreibt die aktivierten Provider als OPML nach path ("-" => Stdout).
+// Parser-Arten ohne Feed (z.B. wp-rest, wordpress-plugins) lassen sich nicht abbilden und werden gemeldet.
+func RunExportOPML(root, path string) error {
+	paths, err := getPaths(root)
+	if err != nil {
+		return err
+	}
+	config, err := loadProvidersFile(paths.providers)
+	if err != nil {
+		return err
+	}
+
+	doc := OPML{Version: "2.0", Head: OPMLHead{Title: loadSite(paths.site).Title}}
+	for _, provider := range config.Providers {
+		if !provider.Enabled {
+			continue
+		}
+		url, ok := feed.FeedURL(provider.Kind, provider.URL)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "skipped provider %q: kind %q has no feed url\n", provider.name(), provider.Kind)
+			continue
+		}
+		doc.Body.Outlines = append(doc.Body.Outlines, OPMLOutline{
+			Text:     provider.name(),
+			Type:     "rss",
+			XMLURL:   url,
+			Category: strings.Join(cleanCategories(provider.Categories), ","),
+		})
+	}
+
+	if path == "-" {
+		return encodeOPML(os.Stdout, doc)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return encodeOPML(file, doc)
+}
+
+func encodeOPML(w io.Writer, doc OPML) error { // XML-Header + eingerücktes OPML.
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func flattenOutlines(outlines []OPMLOutline, folders []string) []OPMLOutline { // Alle Abos; Ordnernamen wandern in die Kategorien.
+	var result []OPMLOutline
+	for _, outline := range outlines {
+		if strings.TrimSpace(outline.XMLURL) != "" {
+			outline.Category = strings.Join(appendCategories(cleanCategories(strings.Split(outline.Category, ",")), folders), ",")
+			result = append(result, outline)
+		}
+		if len(outline.Outlines) > 0 {
+			folder := append(append([]string{}, folders...), outlineTitle(outline))
+			result = append(result, flattenOutlines(outline.Outlines, cleanCategories(folder))...)
+		}
+	}
+	return result
+}
+
+func outlineTitle(outline OPMLOutline) string { // text ist Pflicht, manche Reader setzen nur title.
+	if text := strings.TrimSpace(outline.Text); text != "" {
+		return text
+	}
+	return strings.TrimSpace(outline.Title)
+}
+
+func uniqueName(name string, taken map[string]struct{}) string { // Hängt -2, -3, … an, bis der Name frei ist.
+	if _, exists := taken[name]; !exists {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := name + "-" + strconv.Itoa(i)
+		if _, exists := taken[candidate]; !exists {
+			return candidate
+		}
+	}
+}
+
+func normalizeFeedURL(url string) string { // Vergleichsschlüssel: Schema (http/https), Groß-/Kleinschreibung und Slash am Ende egal.
+	key := strings.ToLower(strings.TrimSpace(url))
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	return strings.TrimRight(key, "/")
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFlattenOutlines(t *testing.T) {
+	outlines := []OPMLOutline{
+		{Text: "Top", XMLURL: "https://example.org/top/feed/"},
+		{Text: "WordPress", Outlines: []OPMLOutline{
+			{Text: "Core", XMLURL: "https://make.wordpress.org/core/feed/", Category: "dev, core"},
+			{Title: "Only title", Outlines: []OPMLOutline{ // Ordner ohne text, nur title.
+				{Text: "Nested", XMLURL: "https://example.org/nested/feed/"},
+			}},
+			{Text: "Empty folder"},
+		}},
+		{Text: "No url"},
+	}
+	got := flattenOutlines(outlines, nil)
+
+	want := []struct{ url, category string }{
+		{url: "https://example.org/top/feed/", category: ""},
+		{url: "https://make.wordpress.org/core/feed/", category: "dev,core,WordPress"},
+		{url: "https://example.org/nested/feed/", category: "WordPress,Only title"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d outlines, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].XMLURL != want[i].url || got[i].Category != want[i].category {
+			t.Errorf("outline %d = %q [%s], want %q [%s]", i, got[i].XMLURL, got[i].Category, want[i].url, want[i].category)
+		}
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	taken := map[string]struct{}{"core": {}, "core-2": {}, "tavern": {}}
+	tests := []struct{ name, want string }{
+		{name: "news", want: "news"},
+		{name: "tavern", want: "tavern-2"},
+		{name: "core", want: "core-3"},
+	}
+	for _, tt := range tests {
+		if got := uniqueName(tt.name, taken); got != tt.want {
+			t.Errorf("uniqueName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeFeedURL(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{a: "https://example.org/feed/", b: "https://example.org/feed", same: true},
+		{a: "https://Example.org/Feed/", b: "https://example.org/feed/", same: true},
+		{a: "http://example.org/feed/", b: "https://example.org/feed/", same: true},
+		{a: " HTTP://example.org/feed ", b: "https://example.org/feed/", same: true},
+		{a: "https://example.org/feed/", b: "https://example.org/other/", same: false},
+		{a: "https://example.org/feed/", b: "https://www.example.org/feed/", same: false},
+	}
+	for _, tt := range tests {
+		if got := normalizeFeedURL(tt.a) == normalizeFeedURL(tt.b); got != tt.same {
+			t.Errorf("normalizeFeedURL(%q) == normalizeFeedURL(%q) = %v, want %v", tt.a, tt.b, got, tt.same)
+		}
+	}
+}
+
+func TestImportExportOPML(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "data", "providers.json"), `{"providers": [
+		{"name": "core", "kind": "rss", "url": "https://make.wordpress.org/core/feed/", "enabled": true},
+		{"name": "plugins", "kind": "wordpress-plugins", "slugs": ["wapuugotchi"], "enabled": true}
+	]}`)
+	writeTestFile(t, filepath.Join(root, "subscriptions.opml"), `<?xml version="1.0"?>
+<opml version="2.0"><head><title>Reader</title></head><body>
+  <outline text="WordPress">
+    <outline text="Core" xmlUrl="http://make.wordpress.org/core/feed"/>
+    <outline text="Core" xmlUrl="https://make.wordpress.org/test/feed/"/>
+    <outline text="WP Tavern" xmlUrl="https://wptavern.com/feed"/>
+  </outline>
+  <outline text="WP Tavern" xmlUrl="https://WPTAVERN.com/feed/"/>
+</body></opml>`)
+
+	if err := RunImportOPML(root, filepath.Join(root, "subscriptions.opml")); err != nil {
+		t.Fatal(err)
+	}
+	config, err := loadProvidersFile(filepath.Join(root, "data", "providers.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, provider := range config.Providers {
+		names = append(names, provider.Name)
+	}
+	// Core (http statt https) und der zweite Tavern sind Duplikate; der zweite "Core" bekommt ein Suffix.
+	if want := []string{"core", "plugins", "core-2", "wp-tavern"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("providers = %v, want %v", names, want)
+	}
+	if got := config.Providers[3].Categories; !reflect.DeepEqual(got, []string{"WordPress"}) {
+		t.Errorf("categories = %v, want folder name", got)
+	}
+
+	exported := filepath.Join(root, "export.opml")
+	if err := RunExportOPML(root, exported); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc OPML
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	var urls []string
+	for _, outline := range doc.Body.Outlines { // Plugins haben keinen Feed und fehlen im Export.
+		urls = append(urls, outline.XMLURL)
+	}
+	if want := []string{"https://make.wordpress.org/core/feed/", "https://make.wordpress.org/test/feed/", "https://wptavern.com/feed"}; !reflect.DeepEqual(urls, want) {
+		t.Fatalf("exported urls = %v, want %v", urls, want)
+	}
+
+	if err := RunImportOPML(root, exported); err != nil { // Re-Import des Exports: alles schon vorhanden.
+		t.Fatal(err)
+	}
+	again, err := loadProvidersFile(filepath.Join(root, "data", "providers.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Providers) != len(config.Providers) {
+		t.Errorf("re-import added providers: %d, want %d", len(again.Providers), len(config.Providers))
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd // Paket "cmd": wählt die Ausgabeformate aus und schreibt sie aus derselben Entry-Liste.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"fmt"           // Fehlertexte für unbekannte Formate.
+	"path/filepath" // Ausgabepfade im Projektroot.
+	"sort"          // Entries nach Datum sortieren.
+	"strings"       // Formatnamen normalisieren.
+)
+
+const ( // Namen der Ausgabeformate, wie sie in site.json/FEED_OUTPUTS stehen.
+	outputRSS  = "rss"
+	outputAtom = "atom"
+	outputJSON = "json"
+)
+
+const ( // Dateinamen der Ausgaben im Projektroot.
+	rssFile      = "feed.xml"
+	atomFile     = "feed.atom"
+	jsonFeedFile = "feed.json"
+)
+
+var defaultOutputs = []string{outputRSS, outputAtom, outputJSON} // Ohne Konfiguration werden alle Formate geschrieben.
+
+type outputFormat struct { // Ein Ausgabeformat: Dateiname + Writer.
+	file  string                                              // Dateiname im Projektroot.
+	write func(site Site, entries []Entry, path string) error // Schreibt das Format aus sortierten Entries.
+}
+
+var outputFormats = map[string]outputFormat{ // Registry: Formatname → Writer.
+	outputRSS:  {file: rssFile, write: writeRSS},
+	outputAtom: {file: atomFile, write: writeAtom},
+	outputJSON: {file: jsonFeedFile, write: writeJSONFeed},
+}
+
+func buildFeed(site Site, entries []Entry, root string) error { // Schreibt alle konfigurierten Ausgaben aus Site + Entries.
+	formats, err := selectOutputs(site.Outputs) // Vorher prüfen: kein halb geschriebener Satz an Dateien bei Tippfehlern.
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { // Sortiert Entries absteigend nach CreatedAt-String.
+		return entries[i].CreatedAt > entries[j].CreatedAt // Stringvergleich funktioniert bei RFC3339 (lexikographisch = chronologisch).
+	})
+
+	for _, format := range formats {
+		if err := format.write(site, entries, filepath.Join(root, format.file)); err != nil {
+			return fmt.Errorf("%s: %w", format.file, err)
+		}
+	}
+	return nil
+}
+
+func selectOutputs(names []string) ([]outputFormat, error) { // Löst Formatnamen auf; leer => defaultOutputs.
+	if len(names) == 0 {
+		names = defaultOutputs
+	}
+	formats := make([]outputFormat, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok { // "rss,rss" nicht doppelt schreiben.
+			continue
+		}
+		format, ok := outputFormats[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown output format %q", name)
+		}
+		seen[name] = struct{}{}
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
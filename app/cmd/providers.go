@@ -0,0 +1,148 @@
+package cmd // Paket "cmd": hier wird die Provider-Konfiguration (data/providers.json) geladen.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/json" // providers.json parsen.
+	"fmt"           // Fehlertexte mit Kontext.
+	"os"            // Datei lesen.
+	"strings"       // Namen/Kinds normalisieren.
+
+	"wapuugotchi/feed/app/env"  // FEED_LOCALE als Default-Sprache.
+	"wapuugotchi/feed/app/feed" // Parser-Registry (feed.LookupParser) und feed.Source.
+)
+
+type providerConfig struct { // Eine Quelle, so wie sie in data/providers.json steht.
+	Name       string           `json:"name"`                 // Eindeutiger Name; landet als Source in entries.json und im ID-Hash.
+	Kind       string           `json:"kind"`                 // Parser-Art (siehe feed.Kinds()).
+	URL        string           `json:"url,omitempty"`        // Feed-URL; leer => Default des Parsers.
+	Slugs      []string         `json:"slugs,omitempty"`      // Plugin-Slugs (Parser-Art "wordpress-plugins").
+	Prompt     string           `json:"prompt,omitempty"`     // Prompt-Name (prompts/<name>.tmpl bzw. eingebaut) oder Inline-Template.
+	Locale     string           `json:"locale,omitempty"`     // Zielsprache für den Prompt ({{.Locale}}); leer => FEED_LOCALE bzw. "en".
+	Enabled    bool             `json:"enabled"`              // Nur aktivierte Quellen werden abgefragt.
+	MaxItems   int              `json:"max_items,omitempty"`  // Maximale Anzahl Items pro Lauf.
+	Categories []string         `json:"categories,omitempty"` // Zusätzliche Kategorien für jedes Item dieser Quelle.
+	Retention  *retentionConfig `json:"retention,omitempty"`  // Wie viele Entries dieser Quelle aufbewahrt werden; nil => alle.
+}
+
+type providersFile struct { // Root-Objekt von data/providers.json.
+	Providers []providerConfig `json:"providers"`
+	Fetch     fetchConfig      `json:"fetch"`         // HTTP-Timeouts und Retry-Policy für alle Quellen.
+	Core      coreConfig       `json:"core_versions"` // Abgleich der Release-Entries mit der stable-check API.
+}
+
+type feedProvider struct { // Abstraktion einer aktivierten Quelle: Konfiguration + Parser.
+	Name       string      // Name wird u.a. in ID-Hash einbezogen (stabil pro Quelle).
+	Kind       string      // Parser-Art, z.B. für Release-Sonderbehandlung.
+	Source     feed.Source // Wird unverändert an den Parser übergeben.
+	Categories []string    // Zusätzliche Kategorien aus der Konfiguration.
+	Fetch      feed.Parser // Parser nimmt Source + fetch-Funktion (Dependency Injection) und liefert feed.Items.
+}
+
+func (c providerConfig) name() string { // Name ist optional: fällt auf die Parser-Art zurück.
+	if name := strings.TrimSpace(c.Name); name != "" {
+		return name
+	}
+	return strings.TrimSpace(c.Kind)
+}
+
+func defaultProviderConfigs() []providerConfig { // Eingebaute Defaults, falls data/providers.json fehlt.
+	return []providerConfig{
+		{Name: releasesProvider, Kind: feed.KindReleases, Enabled: true, Retention: &retentionConfig{Keep: 1, By: retainByChannel}},
+		{Name: "wordpress-tv", Kind: feed.KindWordPressTV},
+		{Name: "wordpress-com", Kind: feed.KindWordPressCom},
+	}
+}
+
+func loadProvidersFile(path string) (providersFile, error) { // Lädt die Provider-Konfiguration.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) { // Datei ist optional…
+			return providersFile{Providers: defaultProviderConfigs(), Core: coreConfig{Enabled: true}}, nil // …dann gelten die eingebauten Defaults.
+		}
+		return providersFile{}, err
+	}
+
+	var file providersFile
+	if err := json.Unmarshal(data, &file); err != nil { // Kaputte Konfiguration nicht still ignorieren.
+		return providersFile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+func providers(configs []providerConfig, prompts feed.Prompts) ([]feedProvider, error) { // Baut aus der Konfiguration die Liste aktiver Quellen.
+	result := make([]feedProvider, 0, len(configs))
+	seen := make(map[string]struct{}, len(configs)) // Doppelte Namen würden IDs/Sources vermischen.
+	for _, config := range configs {
+		kind := strings.TrimSpace(config.Kind)
+		name := config.name()
+		if _, exists := seen[name]; exists {
+			return nil, fmt.Errorf("provider %q: duplicate name", name)
+		}
+		seen[name] = struct{}{}
+
+		parser, ok := feed.LookupParser(kind)
+		if !ok { // Auch deaktivierte Quellen prüfen, damit Tippfehler früh auffallen.
+			return nil, fmt.Errorf("provider %q: unknown kind %q (known: %s)", name, kind, strings.Join(feed.Kinds(), ", "))
+		}
+		if feed.NeedsURL(kind) && strings.TrimSpace(config.URL) == "" { // Generische Parser haben keine Default-URL.
+			return nil, fmt.Errorf("provider %q: kind %q needs a url", name, kind)
+		}
+		slugs := cleanSlugs(config.Slugs)
+		if feed.NeedsSlugs(kind) && len(slugs) == 0 { // Ohne Slugs gäbe es nichts abzufragen.
+			return nil, fmt.Errorf("provider %q: kind %q needs slugs", name, kind)
+		}
+		if err := config.Retention.validate(); err != nil {
+			return nil, fmt.Errorf("provider %q: %w", name, err)
+		}
+		prompt, err := prompts.Resolve(config.Prompt) // Name oder Inline-Template; Fehler auch für deaktivierte Quellen melden.
+		if err != nil {
+			return nil, fmt.Errorf("provider %q: %w", name, err)
+		}
+		if !config.Enabled {
+			continue
+		}
+
+		result = append(result, feedProvider{
+			Name: name,
+			Kind: kind,
+			Source: feed.Source{
+				Name:     name,
+				URL:      config.URL,
+				Prompt:   prompt,
+				Prompts:  prompts,
+				Locale:   providerLocale(config.Locale),
+				MaxItems: config.MaxItems,
+				Slugs:    slugs,
+			},
+			Categories: cleanCategories(config.Categories),
+			Fetch:      parser,
+		})
+	}
+	return result, nil
+}
+
+func providerLocale(locale string) string { // Sprache der Quelle, sonst FEED_LOCALE, sonst Englisch.
+	if locale = strings.TrimSpace(locale); locale != "" {
+		return locale
+	}
+	if locale = env.ReadEnv("FEED_LOCALE"); locale != "" {
+		return locale
+	}
+	return "en"
+}
+
+func cleanSlugs(slugs []string) []string { // Slugs normalisieren (trimmen, klein schreiben) und Duplikate entfernen.
+	result := make([]string, 0, len(slugs))
+	seen := make(map[string]struct{}, len(slugs))
+	for _, slug := range slugs {
+		slug = strings.ToLower(strings.Trim(strings.TrimSpace(slug), "/"))
+		if slug == "" {
+			continue
+		}
+		if _, exists := seen[slug]; exists {
+			continue
+		}
+		seen[slug] = struct{}{}
+		result = append(result, slug)
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+package cmd // Paket "cmd": strukturierte Release-Daten (Version, Kanal, RC-/Beta-Nummer) aus Titel und Kategorien.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"regexp"  // RC-/Beta-Labels im Titel.
+	"strconv" // RC-/Beta-Nummer.
+	"strings" // Normalisieren von Titeln/Kategorien.
+
+	"wapuugotchi/feed/app/feed" // feed.ReleaseVersion.
+)
+
+const ( // Release-Kanäle (Release.Channel und Gruppierung "channel" der Retention).
+	channelStable      = "stable"
+	channelRC          = "rc"
+	channelBeta        = "beta"
+	channelSecurity    = "security"
+	channelMaintenance = "maintenance"
+	channelOther       = "other" // Entries der Quelle, die keine Release-Posts sind.
+)
+
+var ( // Labels im Titel, z.B. "WordPress 7.0 Release Candidate 4", "WordPress 6.5 Beta 2", "WordPress 6.4 RC2".
+	betaPattern   = regexp.MustCompile(`(?i)\bbeta\s*\d*\b`)
+	rcPattern     = regexp.MustCompile(`(?i)\b(release candidate|rc\s*\d*)\b`)
+	numberPattern = regexp.MustCompile(`(?i)\b(?:beta|rc|release candidate)\s*(\d+)\b`)
+)
+
+func parseRelease(entry Entry) *Release { // Release-Daten eines Entries; nil, wenn er kein WordPress-Release beschreibt.
+	if !releaseSource(entry) {
+		return nil
+	}
+	version := feed.ReleaseVersion(entry.Title)
+	if version == "" { // Release-Kategorie, aber keine Versionsnummer im Titel (z.B. Ankündigungen).
+		return nil
+	}
+	release := &Release{
+		Version: semanticVersion(version),
+		Channel: releaseChannel(entry.Title, entry.Categories),
+	}
+	release.Security = release.Channel == channelSecurity
+	if release.prerelease() {
+		if match := numberPattern.FindStringSubmatch(entry.Title); match != nil {
+			release.Number, _ = strconv.Atoi(match[1])
+		}
+	}
+	return release
+}
+
+func releaseSource(entry Entry) bool { // Kommt der Entry aus der Release-Quelle oder ist er als Release kategorisiert?
+	if strings.TrimSpace(entry.Source) == releasesProvider {
+		return true
+	}
+	return containsFold(entry.Categories, "release") || containsFold(entry.Categories, "releases")
+}
+
+func releaseChannel(title string, categories []string) string { // Ordnet einen Release-Titel einem Kanal zu.
+	lower := strings.ToLower(title)
+	switch {
+	case betaPattern.MatchString(title):
+		return channelBeta
+	case rcPattern.MatchString(title):
+		return channelRC
+	case strings.Contains(lower, "security") || containsFold(categories, "security"):
+		return channelSecurity
+	case strings.Contains(lower, "maintenance") || containsFold(categories, "maintenance"):
+		return channelMaintenance
+	}
+	return channelStable
+}
+
+func semanticVersion(version string) string { // "7.0" → "7.0.0"; WordPress lässt die Patch-Stelle bei Major-Releases weg.
+	if strings.Count(version, ".") == 1 {
+		return version + ".0"
+	}
+	return version
+}
+
+func (r *Release) prerelease() bool { // Beta oder RC: nie die aktuelle stabile Version.
+	return r.Channel == channelBeta || r.Channel == channelRC
+}
+
+func refreshReleases(entries []Entry) bool { // Release-Daten aller Entries neu ableiten (Backfill für ältere entries.json); true bei Änderungen.
+	changed := false
+	for i := range entries {
+		release := parseRelease(entries[i])
+		if release != nil && entries[i].Release != nil { // is_latest kommt aus der stable-check API, nicht aus dem Titel.
+			release.Latest = entries[i].Release.Latest
+		}
+		if sameRelease(entries[i].Release, release) {
+			continue
+		}
+		entries[i].Release = release
+		changed = true
+	}
+	return changed
+}
+
+func sameRelease(a, b *Release) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import "testing"
+
+func TestParseRelease(t *testing.T) {
+	tests := []struct {
+		title      string
+		categories []string
+		source     string // Leer => releasesProvider.
+		want       *Release
+	}{
+		{title: "WordPress 7.0 Release Candidate 4", want: &Release{Version: "7.0.0", Channel: channelRC, Number: 4}},
+		{title: "WordPress 6.4 RC2", want: &Release{Version: "6.4.0", Channel: channelRC, Number: 2}},
+		{title: "WordPress 6.9 Release Candidate", want: &Release{Version: "6.9.0", Channel: channelRC}},
+		{title: "WordPress 6.5 Beta 2", want: &Release{Version: "6.5.0", Channel: channelBeta, Number: 2}},
+		{title: "WordPress 6.5 Released", want: &Release{Version: "6.5.0", Channel: channelStable}},
+		{title: "WordPress 6.8.1 Maintenance Release", want: &Release{Version: "6.8.1", Channel: channelMaintenance}},
+		{title: "WordPress 6.4.3 – Maintenance and Security Release", want: &Release{Version: "6.4.3", Channel: channelSecurity, Security: true}},
+		{title: "WordPress 6.2.1", categories: []string{"Security"}, want: &Release{Version: "6.2.1", Channel: channelSecurity, Security: true}},
+		{title: "People of WordPress: Jane Doe"},
+		{title: "WordPress 6.5 Released", source: "wordpress-com"}, // Fremde Quelle ohne Release-Kategorie.
+		{title: "WordPress 6.5 Released", source: "rss", categories: []string{"Releases"}, want: &Release{Version: "6.5.0", Channel: channelStable}}, // Kategorie reicht.
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			source := tt.source
+			if source == "" {
+				source = releasesProvider
+			}
+			got := parseRelease(Entry{Title: tt.title, Categories: tt.categories, Source: source})
+			if !sameRelease(got, tt.want) {
+				t.Errorf("parseRelease(%q) = %+v, want %+v", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshReleasesKeepsLatest(t *testing.T) { // is_latest kommt aus der stable-check API und darf den Backfill überleben.
+	entries := []Entry{{
+		Title:   "WordPress 6.8.1 Maintenance Release",
+		Source:  releasesProvider,
+		Release: &Release{Version: "6.8.1", Channel: channelStable, Latest: true},
+	}}
+	if !refreshReleases(entries) {
+		t.Fatal("refreshReleases: want change (channel), got none")
+	}
+	want := &Release{Version: "6.8.1", Channel: channelMaintenance, Latest: true}
+	if !sameRelease(entries[0].Release, want) {
+		t.Errorf("Release = %+v, want %+v", entries[0].Release, want)
+	}
+	if refreshReleases(entries) {
+		t.Error("refreshReleases: second run should not change anything")
+	}
+}
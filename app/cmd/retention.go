@@ -0,0 +1,94 @@
+package cmd // Paket "cmd": Retention-Policy, damit Quellen sich nicht gegenseitig die Entries löschen.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"fmt"     // Fehlertexte für ungültige Konfiguration.
+	"sort"    // Gruppen nach Datum sortieren.
+	"strings" // Normalisieren von Titeln/Kategorien.
+)
+
+const ( // Gruppierungen für retentionConfig.By.
+	retainBySource  = "source"  // Die letzten N Entries der Quelle behalten.
+	retainByChannel = "channel" // Die letzten N Entries pro Release-Kanal behalten.
+)
+
+type retentionConfig struct { // Retention-Policy einer Quelle aus data/providers.json.
+	Keep int    `json:"keep"`         // Anzahl Entries pro Gruppe; <= 0 => unbegrenzt.
+	By   string `json:"by,omitempty"` // "source" (Default) oder "channel".
+}
+
+func (r *retentionConfig) validate() error { // Prüft die Gruppierung; nil bedeutet "alles behalten".
+	if r == nil {
+		return nil
+	}
+	switch r.groupBy() {
+	case retainBySource, retainByChannel:
+		return nil
+	}
+	return fmt.Errorf("retention: unknown grouping %q (use %q or %q)", r.By, retainBySource, retainByChannel)
+}
+
+func (r *retentionConfig) groupBy() string { // Gruppierung mit Default "source".
+	by := strings.ToLower(strings.TrimSpace(r.By))
+	if by == "" {
+		return retainBySource
+	}
+	return by
+}
+
+func applyRetention(entries *[]Entry, configs []providerConfig) int { // Entfernt überzählige Entries pro Quelle; liefert die Anzahl entfernter Entries.
+	policies := make(map[string]*retentionConfig, len(configs))
+	for _, config := range configs { // Auch deaktivierte Quellen: ihre alten Entries liegen weiter in entries.json.
+		if config.Retention != nil && config.Retention.Keep > 0 {
+			policies[config.name()] = config.Retention
+		}
+	}
+	if len(policies) == 0 {
+		return 0
+	}
+
+	groups := make(map[string][]int) // Gruppen-Key → Indizes in entries.
+	for i, entry := range *entries {
+		policy, ok := policies[entry.Source]
+		if !ok { // Quellen ohne Policy (oder Artikel) bleiben unangetastet.
+			continue
+		}
+		key := entry.Source
+		if policy.groupBy() == retainByChannel {
+			key += "|" + retentionChannel(entry)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	drop := make(map[int]struct{})
+	for key, indices := range groups {
+		keep := policies[strings.SplitN(key, "|", 2)[0]].Keep
+		if len(indices) <= keep {
+			continue
+		}
+		sort.SliceStable(indices, func(i, j int) bool { // Neueste zuerst (RFC3339 ist lexikographisch sortierbar).
+			return (*entries)[indices[i]].CreatedAt > (*entries)[indices[j]].CreatedAt
+		})
+		for _, index := range indices[keep:] {
+			drop[index] = struct{}{}
+		}
+	}
+	if len(drop) == 0 {
+		return 0
+	}
+
+	kept := make([]Entry, 0, len(*entries)-len(drop)) // Reihenfolge der verbleibenden Entries beibehalten.
+	for i, entry := range *entries {
+		if _, ok := drop[i]; !ok {
+			kept = append(kept, entry)
+		}
+	}
+	*entries = kept
+	return len(drop)
+}
+
+func retentionChannel(entry Entry) string { // Release-Kanal eines Entries; Nicht-Releases bilden eine eigene Gruppe.
+	if entry.Release == nil {
+		return channelOther
+	}
+	return entry.Release.Channel
+}
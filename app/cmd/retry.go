@@ -0,0 +1,152 @@
+package cmd // Paket "cmd": Retry-Policy für HTTP-Abrufe (Backoff, Jitter, Retry-After, Deadline).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/json" // Dauer-Werte aus providers.json.
+	"errors"        // errors.As/Is für Netzwerkfehler.
+	"fmt"           // Fehlertexte.
+	"io"            // io.ErrUnexpectedEOF bei abgebrochenen Verbindungen.
+	"math/rand"     // Jitter.
+	"net"           // net.Error für Timeouts.
+	"net/http"      // Statuscodes + Retry-After (HTTP-Datum).
+	"strconv"       // Retry-After in Sekunden.
+	"strings"       // Header trimmen.
+	"syscall"       // ECONNRESET.
+	"time"          // Wartezeiten.
+)
+
+const ( // Defaults, wenn data/providers.json keinen "fetch"-Block hat.
+	defaultFetchAttempts = 4                // 1 Versuch + 3 Retries.
+	defaultBaseDelay     = time.Second      // Wartezeit vor dem ersten Retry (verdoppelt sich je Versuch).
+	defaultMaxDelay      = 30 * time.Second // Obergrenze für eine einzelne Wartezeit.
+	defaultFetchTimeout  = 15 * time.Second // Timeout pro Request.
+	defaultFetchDeadline = 5 * time.Minute  // Gesamtbudget für alle Abrufe eines Laufs.
+)
+
+type duration time.Duration // Dauer in JSON als String ("1.5s", "2m") oder Zahl in Sekunden.
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil { // Kein String: Sekunden als Zahl akzeptieren.
+		var seconds float64
+		if err := json.Unmarshal(data, &seconds); err != nil {
+			return fmt.Errorf("invalid duration %s", data)
+		}
+		*d = duration(seconds * float64(time.Second))
+		return nil
+	}
+	parsed, err := time.ParseDuration(strings.TrimSpace(text))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", text)
+	}
+	*d = duration(parsed)
+	return nil
+}
+
+func (d duration) MarshalJSON() ([]byte, error) { // Symmetrisch zum Lesen: als lesbarer String schreiben.
+	text := time.Duration(d).String()
+	if strings.HasSuffix(text, "m0s") { // "5m0s" → "5m", so wie man es von Hand schreibt.
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") { // "1h0m" → "1h".
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return json.Marshal(text)
+}
+
+type fetchConfig struct { // "fetch"-Block in data/providers.json; leere Felder => Defaults.
+	Attempts  int      `json:"attempts,omitempty"`   // Maximale Versuche pro URL (inkl. dem ersten).
+	BaseDelay duration `json:"base_delay,omitempty"` // Start-Wartezeit für exponentiellen Backoff.
+	MaxDelay  duration `json:"max_delay,omitempty"`  // Obergrenze pro Wartezeit (auch für Retry-After).
+	Timeout   duration `json:"timeout,omitempty"`    // Timeout pro Request.
+	Deadline  duration `json:"deadline,omitempty"`   // Gesamtbudget für alle Abrufe eines Laufs.
+	Workers   int      `json:"workers,omitempty"`    // Provider, die parallel abgerufen und transformiert werden.
+	PerHost   int      `json:"per_host,omitempty"`   // Gleichzeitige Requests pro Host.
+}
+
+type retryPolicy struct { // Aufgelöste Policy für einen Lauf.
+	attempts  int
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	timeout   time.Duration
+	deadline  time.Time // Absoluter Zeitpunkt, ab dem keine Requests/Retries mehr starten.
+}
+
+func newRetryPolicy(config fetchConfig, now time.Time) retryPolicy { // Setzt Defaults ein und fixiert die Deadline.
+	policy := retryPolicy{
+		attempts:  config.Attempts,
+		baseDelay: time.Duration(config.BaseDelay),
+		maxDelay:  time.Duration(config.MaxDelay),
+		timeout:   time.Duration(config.Timeout),
+	}
+	if policy.attempts <= 0 {
+		policy.attempts = defaultFetchAttempts
+	}
+	if policy.baseDelay <= 0 {
+		policy.baseDelay = defaultBaseDelay
+	}
+	if policy.maxDelay <= 0 {
+		policy.maxDelay = defaultMaxDelay
+	}
+	if policy.timeout <= 0 {
+		policy.timeout = defaultFetchTimeout
+	}
+	deadline := time.Duration(config.Deadline)
+	if deadline <= 0 {
+		deadline = defaultFetchDeadline
+	}
+	policy.deadline = now.Add(deadline)
+	return policy
+}
+
+func (p retryPolicy) backoff(attempt int) time.Duration { // Exponentiell (base * 2^attempt), gedeckelt, mit Jitter.
+	delay := p.maxDelay
+	if attempt < 30 { // Shift-Überlauf vermeiden; danach ist ohnehin maxDelay erreicht.
+		if next := p.baseDelay << attempt; next > 0 && next < delay {
+			delay = next
+		}
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1)) // "Equal jitter": zwischen 50 % und 100 %.
+}
+
+func (p retryPolicy) wait(attempt int, retryAfter string, now time.Time) time.Duration { // Wartezeit vor dem nächsten Versuch.
+	if delay, ok := parseRetryAfter(retryAfter, now); ok { // Server-Vorgabe hat Vorrang…
+		if delay > p.maxDelay { // …aber nicht unbegrenzt.
+			delay = p.maxDelay
+		}
+		return delay
+	}
+	return p.backoff(attempt)
+}
+
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) { // Retry-After: Sekunden oder HTTP-Datum.
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := at.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true // Datum liegt in der Vergangenheit: sofort erneut versuchen.
+}
+
+func retryableStatus(code int) bool { // 429 und transiente Serverfehler.
+	return code == http.StatusTooManyRequests || (code >= 500 && code != http.StatusNotImplemented)
+}
+
+func retryableError(err error) bool { // Timeouts, Verbindungsabbrüche, abgeschnittene Antworten.
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "120", want: 2 * time.Minute, wantOK: true},
+		{value: " 0 ", want: 0, wantOK: true},
+		{value: "Fri, 10 Apr 2026 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{value: "Friday, 10-Apr-26 12:01:00 GMT", want: time.Minute, wantOK: true}, // RFC 850 erlaubt http.ParseTime auch.
+		{value: "Fri, 10 Apr 2026 11:00:00 GMT", want: 0, wantOK: true},            // Vergangenheit: sofort.
+		{value: "-5"},
+		{value: "1.5"},
+		{value: "soon"},
+		{value: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %s, %v; want %s, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := retryPolicy{baseDelay: time.Second, maxDelay: 10 * time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration // Obergrenze ohne Jitter; Jitter liegt zwischen 50 % und 100 %.
+	}{
+		{attempt: 0, max: time.Second},
+		{attempt: 1, max: 2 * time.Second},
+		{attempt: 3, max: 8 * time.Second},
+		{attempt: 4, max: 10 * time.Second},  // 16s gedeckelt.
+		{attempt: 40, max: 10 * time.Second}, // Kein Shift-Überlauf.
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.attempt), func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				if got := policy.backoff(tt.attempt); got < tt.max/2 || got > tt.max {
+					t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, got, tt.max/2, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitCapsRetryAfter(t *testing.T) {
+	now := time.Now()
+	policy := retryPolicy{baseDelay: time.Second, maxDelay: 10 * time.Second}
+	if got := policy.wait(0, "3600", now); got != 10*time.Second {
+		t.Errorf("wait with Retry-After 3600 = %s, want max delay", got)
+	}
+	if got := policy.wait(0, "2", now); got != 2*time.Second {
+		t.Errorf("wait with Retry-After 2 = %s, want 2s", got)
+	}
+}
+
+func TestRetryableStatus(t *testing.T) {
+	for code, want := range map[int]bool{
+		http.StatusOK:                  false,
+		http.StatusNotModified:         false,
+		http.StatusBadRequest:          false,
+		http.StatusForbidden:           false,
+		http.StatusNotFound:            false,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusNotImplemented:      false,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	} {
+		if got := retryableStatus(code); got != want {
+			t.Errorf("retryableStatus(%d) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "timeout", err: &url.Error{Op: "Get", URL: "https://example.org", Err: timeoutError{}}, want: true},
+		{name: "connection reset", err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}, want: true},
+		{name: "unexpected eof", err: fmt.Errorf("reading body: %w", io.ErrUnexpectedEOF), want: true},
+		{name: "eof", err: io.EOF, want: true},
+		{name: "dns", err: &net.DNSError{Err: "no such host", Name: "example.invalid"}, want: false},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, want: false},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "other", err: errors.New("boom"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryableError(tt.err); got != tt.want {
+				t.Errorf("retryableError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationJSON(t *testing.T) {
+	var config fetchConfig
+	if err := json.Unmarshal([]byte(`{"base_delay": "1.5s", "deadline": 120}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	if time.Duration(config.BaseDelay) != 1500*time.Millisecond || time.Duration(config.Deadline) != 2*time.Minute {
+		t.Errorf("config = %+v", config)
+	}
+	if err := json.Unmarshal([]byte(`{"timeout": "soon"}`), &config); err == nil {
+		t.Error("invalid duration: want error")
+	}
+	data, _ := json.Marshal(duration(5 * time.Minute))
+	if string(data) != `"5m"` {
+		t.Errorf("Marshal(5m) = %s", data)
+	}
+}
@@ -0,0 +1,73 @@
+package cmd // Paket "cmd": lokale Vorschau der erzeugten Feeds per HTTP.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"context"       // Beenden bei SIGINT/SIGTERM.
+	"errors"        // http.ErrServerClosed erkennen.
+	"fmt"           // Startmeldung.
+	"net/http"      // Dateiserver.
+	"path/filepath" // Pfad der ausgelieferten Datei.
+	"strings"       // Request-Pfad zerlegen.
+	"time"          // Timeouts.
+)
+
+const defaultServeAddr = "127.0.0.1:8080" // Nur lokal erreichbar; --addr :8080 für alle Interfaces.
+
+const indexFile = "index.html" // Startseite mit Links auf die Feeds.
+
+var previewTypes = map[string]string{ // Ausgelieferte Dateien und ihre Content-Types (Go kennt .atom nicht, feed.json wäre nur application/json).
+	indexFile:    "text/html; charset=utf-8",
+	rssFile:      "application/rss+xml; charset=utf-8",
+	atomFile:     "application/atom+xml; charset=utf-8",
+	jsonFeedFile: "application/feed+json; charset=utf-8",
+}
+
+// RunServe liefert index.html und die Feed-Dateien aus dem Projektroot unter addr aus, bis ctx endet.
+// Alles andere im Root (.env, .git/, data/, …) bleibt unerreichbar, auch mit --addr :8080.
+func RunServe(ctx context.Context, root, addr string) error {
+	paths, err := getPaths(root)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           previewHandler(paths.root),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errc := make(chan error, 1)
+	go func() { errc <- server.ListenAndServe() }()
+	fmt.Printf("serving %s on http://%s/ (Ctrl-C to stop)\n", paths.root, addr)
+
+	select {
+	case err := <-errc: // z.B. Port belegt.
+		return err
+	case <-ctx.Done(): // Ctrl-C ist hier das normale Ende, kein Fehler.
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdown); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+func previewHandler(root string) http.Handler { // Nur die Dateien aus previewTypes; keine Verzeichnislisten, keine Dot-Pfade.
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		name := strings.TrimPrefix(r.URL.Path, "/")
+		if name == "" {
+			name = indexFile
+		}
+		contentType, ok := previewTypes[name] // Exakter Name im Root: "data/entries.json" oder "/.env" treffen nie.
+		if !ok || strings.HasPrefix(name, ".") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		http.ServeFile(w, r, filepath.Join(root, name))
+	})
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPreviewHandler(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"index.html":        "<h1>index</h1>",
+		"feed.xml":          "<rss/>",
+		"feed.json":         "{}",
+		".env":              "GH_MODELS_TOKEN=secret",
+		"data/entries.json": "[]",
+		".git/config":       "[core]",
+		"go.mod":            "module x",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handler := previewHandler(root)
+
+	tests := []struct {
+		method      string
+		path        string
+		status      int
+		contentType string
+	}{
+		{method: http.MethodGet, path: "/", status: http.StatusOK, contentType: "text/html; charset=utf-8"},
+		{method: http.MethodGet, path: "/feed.xml", status: http.StatusOK, contentType: "application/rss+xml; charset=utf-8"},
+		{method: http.MethodHead, path: "/feed.json", status: http.StatusOK, contentType: "application/feed+json; charset=utf-8"},
+		{method: http.MethodGet, path: "/feed.atom", status: http.StatusNotFound}, // Nicht erzeugt.
+		{method: http.MethodGet, path: "/.env", status: http.StatusNotFound},
+		{method: http.MethodGet, path: "/.git/config", status: http.StatusNotFound},
+		{method: http.MethodGet, path: "/data/entries.json", status: http.StatusNotFound},
+		{method: http.MethodGet, path: "/data/", status: http.StatusNotFound},
+		{method: http.MethodGet, path: "/go.mod", status: http.StatusNotFound},
+		{method: http.MethodGet, path: "/../feed.xml", status: http.StatusNotFound},
+		{method: http.MethodPost, path: "/feed.xml", status: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://localhost/", nil)
+			req.URL.Path = tt.path // Ungefiltert, wie ein manipulierter Client ihn schicken könnte.
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.contentType != "" && rec.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", rec.Header().Get("Content-Type"), tt.contentType)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package cmd // Paket "cmd": gelöschte Entries (data/deleted.json), damit sie bei Updates und Rebuilds nicht zurückkommen.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"fmt"     // Fehlertexte beim Auflösen von IDs.
+	"sort"    // Einträge nach Datum sortieren.
+	"strings" // ID-Präfixe vergleichen.
+	"time"    // Löschzeitpunkt.
+
+	"wapuugotchi/feed/app/feed" // feed.Item für die Filterung frisch geholter Items.
+)
+
+const shortIDLength = 8 // So viele Zeichen der ID zeigt "list"; reicht praktisch immer zum eindeutigen Auflösen.
+
+const minIDPrefix = 6 // Kürzere Präfixe sind zu leicht mit alten Positionsnummern ("delete 3") zu verwechseln.
+
+type tombstone struct { // Ein gelöschter Entry; Titel/Quelle nur zur Orientierung beim Lesen der Datei.
+	ID        string `json:"id"`
+	Source    string `json:"source,omitempty"`
+	Title     string `json:"title,omitempty"`
+	DeletedAt string `json:"deleted_at"` // RFC3339.
+}
+
+type deletedIDs map[string]struct{} // Schnelle Prüfung "ist diese ID gelöscht?".
+
+func loadTombstones(path string) []tombstone { // Fehlt die Datei, ist nichts gelöscht.
+	tombstones := []tombstone{}
+	readJSON(path, &tombstones)
+	return tombstones
+}
+
+func loadDeletedIDs(path string) deletedIDs {
+	tombstones := loadTombstones(path)
+	deleted := make(deletedIDs, len(tombstones))
+	for _, tombstone := range tombstones {
+		deleted[tombstone.ID] = struct{}{}
+	}
+	return deleted
+}
+
+func (d deletedIDs) has(id string) bool {
+	_, ok := d[id]
+	return ok
+}
+
+func (d deletedIDs) entries(entries []Entry) []Entry { // entries ohne gelöschte.
+	if len(d) == 0 {
+		return entries
+	}
+	result := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if !d.has(entry.ID) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func (d deletedIDs) items(provider string, items []feed.Item) []feed.Item { // Frisch geholte Items ohne gelöschte.
+	if len(d) == 0 {
+		return items
+	}
+	result := make([]feed.Item, 0, len(items))
+	for _, item := range items {
+		if !d.has(pickEntryID(provider, item)) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func liveEntries(paths Paths) []Entry { // entries.json + Artikel ohne gelöschte, neueste zuerst – das, was in den Feeds steht.
+	deleted := loadDeletedIDs(paths.deleted)
+	entries := loadEntries(paths.entries)
+	refreshReleases(entries) // Nur für die Ausgabe; entries.json schreibt erst der nächste update-Lauf.
+	live := mergeEntries(deleted.entries(entries), deleted.entries(loadArticleEntries(paths.articles)))
+	sort.SliceStable(live, func(i, j int) bool {
+		return live[i].CreatedAt > live[j].CreatedAt
+	})
+	return live
+}
+
+func findEntry(entries []Entry, prefix string) (Entry, error) { // Entry per voller ID oder eindeutigem Präfix.
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	for _, entry := range entries { // Volle ID gewinnt immer, auch wenn sie kürzer als minIDPrefix ist (Artikel-IDs sind frei wählbar).
+		if prefix != "" && strings.ToLower(entry.ID) == prefix { // Artikel-IDs auch in Großbuchstaben.
+			return entry, nil
+		}
+	}
+	if len(prefix) < minIDPrefix { // Mindestlänge gilt nur für Präfixe.
+		return Entry{}, fmt.Errorf("id %q is too short (use at least %d characters of the id shown by list)", prefix, minIDPrefix)
+	}
+	var matches []Entry
+	for _, entry := range entries {
+		if strings.HasPrefix(strings.ToLower(entry.ID), prefix) {
+			matches = append(matches, entry)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Entry{}, fmt.Errorf("no entry with id %q", prefix)
+	case 1:
+		return matches[0], nil
+	default:
+		return Entry{}, fmt.Errorf("id %q is ambiguous (%d entries match)", prefix, len(matches))
+	}
+}
+
+func newTombstone(entry Entry) tombstone {
+	return tombstone{
+		ID:        entry.ID,
+		Source:    entry.Source,
+		Title:     entry.Title,
+		DeletedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+func shortID(id string) string { // Gekürzte ID für Listen und Meldungen.
+	if len(id) > shortIDLength {
+		return id[:shortIDLength]
+	}
+	return id
+}
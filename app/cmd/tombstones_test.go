@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindEntry(t *testing.T) {
+	entries := []Entry{
+		{ID: "3f9a1c2e7b", Title: "first"},
+		{ID: "3f9a1c99aa", Title: "second"},
+		{ID: "3F9A1C2E", Title: "prefix of first"}, // Volle ID, zugleich Präfix von "first".
+		{ID: "b71d04e5c8", Title: "third"},
+		{ID: "Hello", Title: "short article id"}, // Explizite Front-Matter-ID unter minIDPrefix.
+	}
+	tests := []struct {
+		prefix  string
+		want    string // Titel des Treffers; leer => Fehler erwartet.
+		wantErr string
+	}{
+		{prefix: "b71d04", want: "third"},
+		{prefix: "  B71D04E5C8 ", want: "third"}, // Groß/klein und Leerzeichen egal.
+		{prefix: "3f9a1c2e", want: "prefix of first"},
+		{prefix: "3f9a1c2e7", want: "first"},
+		{prefix: "3f9a1c", wantErr: "ambiguous (3 entries match)"},
+		{prefix: "3f9a1", wantErr: "too short"},
+		{prefix: "hello", want: "short article id"}, // Volle ID: Mindestlänge gilt nicht.
+		{prefix: "hell", wantErr: "too short"},      // Präfix der kurzen ID: schon.
+		{prefix: "", wantErr: "too short"},
+		{prefix: "ffffff", wantErr: "no entry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			got, err := findEntry(entries, tt.prefix)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("findEntry(%q) error = %v, want %q", tt.prefix, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findEntry(%q): %v", tt.prefix, err)
+			}
+			if got.Title != tt.want {
+				t.Errorf("findEntry(%q) = %q, want %q", tt.prefix, got.Title, tt.want)
+			}
+		})
+	}
+}
+
+func TestShortID(t *testing.T) {
+	if got := shortID("0123456789abcdef"); got != "01234567" {
+		t.Errorf("shortID = %q, want first %d characters", got, shortIDLength)
+	}
+	if got := shortID("abc"); got != "abc" {
+		t.Errorf("shortID of a short id = %q, want it unchanged", got)
+	}
+}
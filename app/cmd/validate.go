@@ -0,0 +1,125 @@
+package cmd // Paket "cmd": Konfiguration und Daten prüfen, ohne etwas zu schreiben.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/json" // Dateien streng parsen (readJSON ignoriert Fehler).
+	"fmt"           // Problemtexte.
+	"os"            // Dateien lesen, Meldungen auf Stderr.
+	"path/filepath" // Artikelpfade.
+	"strings"       // Dateiendungen, Trimmen.
+
+	"wapuugotchi/feed/app/feed" // Prompts für die Provider-Prüfung.
+)
+
+// RunValidate prüft site.json, providers.json, Prompts, entries.json, deleted.json und articles/ und meldet jedes Problem.
+// Der Fehler fasst nur die Anzahl zusammen; die Details stehen auf Stderr.
+func RunValidate(root string) error {
+	paths, err := getPaths(root)
+	if err != nil {
+		return err
+	}
+	var problems []error
+	check := func(err error) {
+		if err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	check(validateJSONFile(paths.site, &Site{}))
+	site := loadSite(paths.site) // Inkl. Env-Overrides, so wie update/build sie sehen.
+	if _, err := selectOutputs(site.Outputs); err != nil {
+		check(fmt.Errorf("%s: %w", paths.site, err))
+	}
+	if _, err := rssMode(site); err != nil {
+		check(fmt.Errorf("%s: %w", paths.site, err))
+	}
+
+	config, err := loadProvidersFile(paths.providers)
+	check(err)
+	prompts, promptErr := feed.LoadPrompts(paths.prompts)
+	check(promptErr)
+	if err == nil && promptErr == nil {
+		if _, err := providers(config.Providers, prompts); err != nil {
+			check(fmt.Errorf("%s: %w", paths.providers, err))
+		}
+	}
+
+	var entries []Entry
+	check(validateJSONFile(paths.entries, &entries))
+	check(validateJSONFile(paths.deleted, &[]tombstone{}))
+	for _, err := range validateArticles(paths.articles, entries) {
+		check(err)
+	}
+
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Fprintln(os.Stderr, problem)
+		}
+		return fmt.Errorf("validation failed: %d problem(s)", len(problems))
+	}
+	fmt.Println("all files valid")
+	return nil
+}
+
+func validateJSONFile(path string, target any) error { // Fehlende Datei ist ok (Defaults), kaputtes JSON nicht.
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func validateArticles(dir string, entries []Entry) []error { // Jede Artikel-Datei mit Datei, Feld und Grund; auch was loadArticleEntries still überspringt.
+	list, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return []error{err}
+	}
+	owners := make(map[string]string, len(entries)) // ID → Datei, die sie zuerst vergibt.
+	for _, entry := range entries {
+		owners[entry.ID] = "data/entries.json"
+	}
+
+	var problems []error
+	for _, file := range list {
+		if file.IsDir() || !isArticleFile(file.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		var entry Entry
+		var unknown []string
+		if isMarkdownArticle(file.Name()) {
+			entry, unknown, err = parseMarkdownArticle(data)
+		} else {
+			err = decodeArticle(data, &entry)
+		}
+		if err != nil {
+			problems = append(problems, articleProblem{file: path, reason: err.Error()})
+			continue
+		}
+		for _, key := range unknown {
+			problems = append(problems, articleProblem{file: path, field: key, reason: fmt.Sprintf("unknown front matter key (known: %s)", strings.Join(frontMatterKeys, ", "))})
+		}
+		problems = append(problems, checkArticle(path, entry)...)
+		if id := strings.TrimSpace(entry.ID); id != "" { // Ohne ID wird sie aus dem Dateinamen abgeleitet.
+			if owner, taken := owners[id]; taken { // mergeEntries würde diesen Artikel still verwerfen.
+				problems = append(problems, articleProblem{file: path, field: "id", reason: fmt.Sprintf("%q is already used by %s", id, owner)})
+				continue
+			}
+			owners[id] = path
+		}
+	}
+	return problems
+}
@@ -0,0 +1,82 @@
+package cmd // Paket "cmd": paralleles Abrufen/Transformieren der Provider mit begrenzter Worker-Zahl.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"context" // Abbruch des Laufs.
+	"fmt"     // Fortschritt im Verbose-Modus.
+	"strconv" // FEED_WORKERS parsen.
+	"sync"    // WaitGroup für die Worker.
+
+	"wapuugotchi/feed/app/env"  // FEED_WORKERS.
+	"wapuugotchi/feed/app/feed" // feed.Item + feed.Fetcher.
+)
+
+const ( // Defaults, wenn der "fetch"-Block keine Limits setzt.
+	defaultWorkers = 4 // Provider, die gleichzeitig abgerufen/transformiert werden.
+	defaultPerHost = 2 // Gleichzeitige Requests pro Host (schont z.B. wordpress.org).
+)
+
+type providerResult struct { // Ergebnis eines Providers; Index entspricht der Position in active.
+	items []feed.Item
+	err   error
+	urls  []string // Erfolgreich abgerufene URLs; ihr HTTP-Cache wird erst nach dem Speichern geschrieben.
+}
+
+func fetchProviders(ctx context.Context, active []feedProvider, entries []Entry, deleted deletedIDs, fetch feed.Fetcher, workers int, verbose bool) []providerResult { // Ruft alle Provider parallel ab.
+	results := make([]providerResult, len(active)) // Jeder Worker schreibt nur seinen eigenen Index: kein Lock nötig.
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(active)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				provider := active[i]
+				if err := ctx.Err(); err != nil { // Abgebrochen: restliche Provider gar nicht mehr starten.
+					results[i] = providerResult{err: err}
+					continue
+				}
+				if verbose {
+					fmt.Printf("Processing feed: %s\n", provider.Name)
+				}
+				var urls []string
+				var urlsMu sync.Mutex                                                    // Parser dürfen selbst parallel abrufen.
+				track := func(ctx context.Context, url, source string) ([]byte, error) { // fetch, merkt sich aber jede erfolgreiche URL.
+					body, err := fetch(ctx, url, source)
+					if err == nil {
+						urlsMu.Lock()
+						urls = append(urls, url)
+						urlsMu.Unlock()
+					}
+					return body, err
+				}
+				items, err := latestItems(ctx, provider, entries, deleted, track) // entries/deleted werden hier nur gelesen.
+				results[i] = providerResult{items: items, err: err, urls: urls}
+			}
+		}()
+	}
+	for i := range active {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func (c fetchConfig) workers() int { // Worker-Limit; FEED_WORKERS überschreibt die Konfiguration.
+	if value := env.ReadEnv("FEED_WORKERS"); value != "" {
+		if workers, err := strconv.Atoi(value); err == nil && workers > 0 {
+			return workers
+		}
+	}
+	if c.Workers > 0 {
+		return c.Workers
+	}
+	return defaultWorkers
+}
+
+func (c fetchConfig) perHost() int { // Limit gleichzeitiger Requests pro Host.
+	if c.PerHost > 0 {
+		return c.PerHost
+	}
+	return defaultPerHost
+}
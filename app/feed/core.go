@@ -0,0 +1,67 @@
+package feed // Paket "feed": WordPress-Core-Versionen aus der stable-check API (Status je Version).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"context"       // Abbruch des Abrufs.
+	"encoding/json" // Antwort der API parsen.
+	"fmt"           // Fehlertexte.
+	"regexp"        // Versionsnummer aus Release-Titeln.
+	"strings"       // Trimmen, ".0"-Suffix.
+)
+
+const stableCheckURL = "https://api.wordpress.org/core/stable-check/1.0/" // {"6.8.1":"latest","6.8":"outdated","6.7.1":"insecure",…}
+
+const ( // Status-Werte der stable-check API.
+	VersionLatest   = "latest"   // Aktuelle stabile Version.
+	VersionOutdated = "outdated" // Älter, aber ohne bekannte Sicherheitslücken.
+	VersionInsecure = "insecure" // Älter und mit bekannten Sicherheitslücken.
+)
+
+var releaseVersionPattern = regexp.MustCompile(`(?i)\bWordPress\s+(\d+\.\d+(?:\.\d+)?)\b`) // "WordPress 6.8.1 Maintenance Release".
+
+// CoreVersions bildet WordPress-Versionen ("6.8.1") auf ihren Status aus der stable-check API ab.
+type CoreVersions map[string]string
+
+// LatestCoreVersions fragt die stable-check API ab; eine leere URL nimmt den Endpoint auf api.wordpress.org.
+func LatestCoreVersions(ctx context.Context, url string, fetch Fetcher) (CoreVersions, error) {
+	if url = strings.TrimSpace(url); url == "" {
+		url = stableCheckURL
+	}
+	body, err := fetch(ctx, url, "wordpress core versions")
+	if err != nil {
+		return nil, err
+	}
+	var versions CoreVersions
+	if err := json.Unmarshal(body, &versions); err != nil {
+		return nil, fmt.Errorf("wordpress core versions: %w", err)
+	}
+	if len(versions) == 0 { // Leere Antwort würde jede Version zur "unbekannten" machen.
+		return nil, fmt.Errorf("wordpress core versions: empty response")
+	}
+	return versions, nil
+}
+
+// Status liefert den Status einer Version ("latest", "outdated", "insecure"); leer, wenn die API sie nicht kennt
+// (z.B. Beta/RC). "6.8.0" und "6.8" gelten als dieselbe Version.
+func (v CoreVersions) Status(version string) string {
+	version = strings.TrimSpace(version)
+	if status, ok := v[version]; ok {
+		return status
+	}
+	switch strings.Count(version, ".") {
+	case 1: // "6.8" → "6.8.0"
+		return v[version+".0"]
+	case 2: // "6.8.0" → "6.8"
+		if trimmed, ok := strings.CutSuffix(version, ".0"); ok {
+			return v[trimmed]
+		}
+	}
+	return ""
+}
+
+// ReleaseVersion liest die Versionsnummer aus einem Release-Titel wie "WordPress 6.8.1 Maintenance Release".
+func ReleaseVersion(title string) string {
+	if match := releaseVersionPattern.FindStringSubmatch(title); match != nil {
+		return match[1]
+	}
+	return ""
+}
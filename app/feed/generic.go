@@ -0,0 +1,94 @@
+package feed // Paket "feed": Parser-Art "rss" – beliebige RSS/RDF/Atom-Feeds, nur per URL konfiguriert.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"context" // Abbruch von HTTP- und KI-Calls.
+	"fmt"     // Fehlertexte.
+	"net/url" // Relative Links gegen die Feed-URL auflösen.
+	"os"      // Template-Fehler auf Stderr.
+	"strings" // Trimmen.
+
+	"wapuugotchi/feed/app/ai" // Optionale KI-Aufbereitung, wenn die Quelle einen Prompt hat.
+)
+
+// LatestFeed ist die Parser-Art "rss": holt einen beliebigen Feed und liefert die neuen Einträge als Items.
+// Ohne Prompt wird das Feed-HTML auf die Allowlist reduziert übernommen, mit Prompt geht es durch die KI.
+func LatestFeed(ctx context.Context, src Source, fetch Fetcher) ([]Item, error) {
+	feedURL := strings.TrimSpace(src.URL)
+	if feedURL == "" { // Kein sinnvoller Default für "irgendeinen" Feed.
+		return nil, fmt.Errorf("%s: kind %q needs a url", src.label(KindFeed), KindFeed)
+	}
+
+	body, err := fetch(ctx, feedURL, src.label(feedURL))
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := ParseFeed(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", src.label(feedURL), err)
+	}
+
+	items := make([]Item, 0, src.limit())
+	for _, item := range src.fresh(parsed) {
+		if err := ctx.Err(); err != nil { // Abgebrochen: keine weiteren KI-Calls.
+			return nil, err
+		}
+		item.Title = strings.TrimSpace(item.Title)
+		item.Link = resolveURL(feedURL, item.Link)
+		item.Iframe = resolveURL(feedURL, item.Iframe)
+		for i := range item.Enclosures {
+			item.Enclosures[i].URL = resolveURL(feedURL, item.Enclosures[i].URL)
+		}
+		item.Content = buildFeedContent(ctx, src, item)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func buildFeedContent(ctx context.Context, src Source, item Item) string { // Voller Inhalt, sonst Zusammenfassung; bereinigt.
+	body := strings.TrimSpace(item.Content)
+	if body == "" {
+		body = strings.TrimSpace(item.Summary)
+	}
+	if body == "" {
+		return ""
+	}
+	fallback := SanitizeHTML(body)
+	if src.Prompt == nil { // Ohne konfigurierten Prompt kein KI-Call.
+		return fallback
+	}
+
+	prompt, err := src.renderPrompt("", PromptData{
+		Title:      item.Title,
+		Link:       item.Link,
+		Categories: item.Categories,
+		Body:       body,
+	})
+	if err != nil { // Kaputtes Template: melden, sonst fällt jeder Eintrag unbemerkt auf den Feed-Text zurück.
+		fmt.Fprintf(os.Stderr, "%s: prompt: %v (using original description)\n", src.label("rss"), err)
+		return fallback
+	}
+	rendered, err := ai.Complete(ctx, prompt)
+	if err != nil {
+		return fallback
+	}
+	if cleaned := SanitizeHTML(rendered); cleaned != "" {
+		return cleaned
+	}
+	return fallback
+}
+
+func resolveURL(base, ref string) string { // Relative URLs (Atom xml:base-lose Feeds, Enclosures) absolut machen.
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	parsedRef, err := url.Parse(ref)
+	if err != nil || parsedRef.IsAbs() {
+		return ref
+	}
+	parsedBase, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	return parsedBase.ResolveReference(parsedRef).String()
+}
@@ -0,0 +1,160 @@
+package feed // Paket "feed": Markdown → die HTML-Teilmenge, die SanitizeHTML durchlässt (für Artikel in articles/*.md).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"html"    // Code und URLs escapen.
+	"regexp"  // Block- und Inline-Syntax erkennen.
+	"strconv" // Platzhalter-Nummern.
+	"strings" // Zeilen, Trimmen, Zusammenbauen.
+)
+
+var ( // Block-Syntax (pro Zeile).
+	mdHeading     = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*$`)                         // "## Titel" → <p><strong>Titel</strong></p>.
+	mdRule        = regexp.MustCompile(`^(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`) // "---" trennt nur optisch: entfällt.
+	mdListItem    = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(.*)$`)                 // "- a", "* a", "1. a" → <li>; nummerierte Listen werden zu <ul> (kein <ol> in der Allowlist).
+	mdQuote       = regexp.MustCompile(`^\s*>\s?(.*)$`)                                 // "> Zitat" → normaler Absatz.
+	mdFence       = regexp.MustCompile("^\\s*(```|~~~)")                                // Code-Block-Grenze.
+	mdIndented    = regexp.MustCompile(`^(?: {2,}|\t)\S`)                               // Fortsetzung eines Listenpunkts.
+	mdHardBreak   = regexp.MustCompile(`(?: {2,}|\\)$`)                                 // Harter Zeilenumbruch: ohne <br> nur ein Leerzeichen.
+	mdBackslash   = regexp.MustCompile("\\\\([\\\\`*_\\[\\]()#+\\-.!<>])")              // \* → wörtliches *.
+	mdCode        = regexp.MustCompile("`([^`]+)`")
+	mdImage       = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`) // Bilder gibt es im Subset nicht: Link mit Alt-Text.
+	mdLink        = regexp.MustCompile(`\[([^\]]+)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	mdAutolink    = regexp.MustCompile(`<((?:https?://|mailto:)[^>\s]+)>`)
+	mdStrong      = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	mdEmphasis    = regexp.MustCompile(`\*(\S(?:[^*]*?\S)?)\*|\b_(\S(?:[^_]*?\S)?)_\b`)
+	mdPlaceholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// MarkdownToHTML rendert Markdown (Absätze, Überschriften, Listen, Zitate, Code, Links, Hervorhebungen) in das
+// HTML-Subset von SanitizeHTML. Was das Subset nicht kennt, wird vereinfacht: Überschriften werden fett, Bilder zu Links.
+func MarkdownToHTML(source string) string {
+	var out strings.Builder
+	var paragraph, items, code []string
+	fence := "" // Offener Code-Block ("```" oder "~~~").
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + renderInline(strings.Join(paragraph, " ")) + "</p>")
+			paragraph = nil
+		}
+	}
+	flushList := func() {
+		if len(items) > 0 {
+			out.WriteString("<ul>")
+			for _, item := range items {
+				out.WriteString("<li>" + renderInline(item) + "</li>")
+			}
+			out.WriteString("</ul>")
+			items = nil
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n") {
+		if fence != "" { // Im Code-Block: alles wörtlich.
+			if strings.HasPrefix(strings.TrimSpace(line), fence) {
+				out.WriteString("<p>" + html.EscapeString(strings.Join(code, "\n")) + "</p>")
+				fence, code = "", nil
+				continue
+			}
+			code = append(code, line)
+			continue
+		}
+		if match := mdFence.FindStringSubmatch(line); match != nil {
+			flushParagraph()
+			flushList()
+			fence = match[1]
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		text := strings.TrimSpace(mdHardBreak.ReplaceAllString(trimmed, ""))
+		switch {
+		case trimmed == "":
+			flushParagraph()
+			flushList()
+		case mdHeading.MatchString(trimmed):
+			flushParagraph()
+			flushList()
+			out.WriteString("<p><strong>" + renderInline(mdHeading.FindStringSubmatch(trimmed)[1]) + "</strong></p>")
+		case mdRule.MatchString(trimmed):
+			flushParagraph()
+			flushList()
+		case mdListItem.MatchString(line):
+			flushParagraph()
+			items = append(items, strings.TrimSpace(mdHardBreak.ReplaceAllString(mdListItem.FindStringSubmatch(line)[1], "")))
+		case len(items) > 0 && mdIndented.MatchString(line): // Eingerückte Zeile gehört zum letzten Listenpunkt.
+			items[len(items)-1] += " " + text
+		case mdQuote.MatchString(line):
+			flushList()
+			if quoted := strings.TrimSpace(mdQuote.FindStringSubmatch(line)[1]); quoted != "" {
+				paragraph = append(paragraph, quoted)
+			} else {
+				flushParagraph()
+			}
+		default:
+			flushList()
+			paragraph = append(paragraph, text)
+		}
+	}
+	if fence != "" { // Nicht geschlossener Code-Block: trotzdem ausgeben.
+		out.WriteString("<p>" + html.EscapeString(strings.Join(code, "\n")) + "</p>")
+	}
+	flushParagraph()
+	flushList()
+	return SanitizeHTML(out.String())
+}
+
+func renderInline(text string) string { // Inline-Syntax eines Absatzes/Listenpunkts; Roh-HTML bleibt für SanitizeHTML stehen.
+	text = strings.ReplaceAll(text, "\x00", "") // Reserviert für Platzhalter.
+	var held []string
+	hold := func(value string) string { // Fertige Teile vor weiteren Ersetzungen schützen.
+		held = append(held, value)
+		return "\x00" + strconv.Itoa(len(held)-1) + "\x00"
+	}
+	link := func(label, href string) string {
+		return hold(`<a href="` + html.EscapeString(href) + `">` + label + `</a>`)
+	}
+
+	text = mdBackslash.ReplaceAllStringFunc(text, func(match string) string {
+		return hold(html.EscapeString(match[1:]))
+	})
+	text = mdCode.ReplaceAllStringFunc(text, func(match string) string {
+		return hold(html.EscapeString(mdCode.FindStringSubmatch(match)[1]))
+	})
+	text = mdImage.ReplaceAllStringFunc(text, func(match string) string {
+		parts := mdImage.FindStringSubmatch(match)
+		label := parts[1]
+		if strings.TrimSpace(label) == "" {
+			label = parts[2]
+		}
+		return link(html.EscapeString(label), parts[2])
+	})
+	text = mdLink.ReplaceAllStringFunc(text, func(match string) string {
+		parts := mdLink.FindStringSubmatch(match)
+		return link(renderEmphasis(parts[1]), parts[2])
+	})
+	text = mdAutolink.ReplaceAllStringFunc(text, func(match string) string {
+		href := mdAutolink.FindStringSubmatch(match)[1]
+		return link(html.EscapeString(strings.TrimPrefix(href, "mailto:")), href)
+	})
+	text = renderEmphasis(text)
+
+	for i := 0; i <= len(held) && strings.Contains(text, "\x00"); i++ { // Platzhalter können verschachtelt sein (Code im Link-Text).
+		text = mdPlaceholder.ReplaceAllStringFunc(text, func(match string) string {
+			index, _ := strconv.Atoi(mdPlaceholder.FindStringSubmatch(match)[1])
+			return held[index]
+		})
+	}
+	return text
+}
+
+func renderEmphasis(text string) string { // **fett**, __fett__, *kursiv*, _kursiv_.
+	text = mdStrong.ReplaceAllStringFunc(text, func(match string) string {
+		parts := mdStrong.FindStringSubmatch(match)
+		return "<strong>" + parts[1] + parts[2] + "</strong>"
+	})
+	return mdEmphasis.ReplaceAllStringFunc(text, func(match string) string {
+		parts := mdEmphasis.FindStringSubmatch(match)
+		return "<em>" + parts[1] + parts[2] + "</em>"
+	})
+}
@@ -0,0 +1,302 @@
+package feed // Paket "feed": generischer Parser für RSS 0.9x/2.0, RSS 1.0 (RDF) und Atom 1.0.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"bytes"        // Body als Reader für den Decoder.
+	"encoding/xml" // Feed-XML parsen.
+	"fmt"          // Fehlertexte.
+	"html"         // Atom-Text (type="text") als HTML escapen.
+	"io"           // CharsetReader.
+	"strconv"      // Enclosure-Längen.
+	"strings"      // Trimmen, Charset-Namen normalisieren.
+	"unicode/utf8" // Latin-1 → UTF-8.
+)
+
+// Enclosure ist eine angehängte Datei (RSS <enclosure>, Atom rel="enclosure", media:content).
+type Enclosure struct {
+	URL    string // Absolute URL der Datei.
+	Type   string // MIME-Type, z.B. "audio/mpeg"; kann leer sein.
+	Length int64  // Größe in Bytes; 0 => unbekannt.
+}
+
+type rawDocument struct { // Gemeinsames Root für alle Formate: nur das jeweils passende Feld wird gefüllt.
+	XMLName xml.Name
+	Channel rawChannel `xml:"channel"` // RSS 0.9x/2.0: <rss><channel><item>.
+	Items   []rawItem  `xml:"item"`    // RSS 1.0 (RDF): <item> liegt neben <channel>.
+	Entries []rawEntry `xml:"entry"`   // Atom 1.0: <feed><entry>.
+}
+
+type rawChannel struct {
+	Items []rawItem `xml:"item"`
+}
+
+type rawItem struct { // RSS-Item inkl. der verbreiteten Module (content, dc, media).
+	rawMedia                   // Zuerst: encoding/xml nimmt das erste passende Feld, media:* darf <title> & Co. nicht überschreiben.
+	Title       string         `xml:"title"`
+	Links       []rawLink      `xml:"link"` // <link>URL</link>, manchmal zusätzlich <atom:link href>.
+	PubDate     string         `xml:"pubDate"`
+	Date        string         `xml:"date"` // dc:date (RSS 1.0 und manche RSS-2.0-Feeds).
+	Description string         `xml:"description"`
+	Encoded     string         `xml:"encoded"` // content:encoded.
+	Categories  []string       `xml:"category"`
+	Subjects    []string       `xml:"subject"` // dc:subject.
+	Creator     string         `xml:"creator"` // dc:creator.
+	Author      string         `xml:"author"`  // RSS 2.0 <author> (meist "mail (Name)").
+	Enclosures  []rawEnclosure `xml:"enclosure"`
+}
+
+type rawEntry struct { // Atom-Entry.
+	rawMedia                 // Zuerst, damit media:content nicht im Atom-<content> landet.
+	Title      rawText       `xml:"title"`
+	Links      []rawLink     `xml:"link"`
+	Published  string        `xml:"published"`
+	Updated    string        `xml:"updated"`
+	Summary    rawText       `xml:"summary"`
+	Content    rawText       `xml:"content"`
+	Categories []rawCategory `xml:"category"`
+	Authors    []rawPerson   `xml:"author"`
+}
+
+type rawMedia struct { // Media RSS (http://search.yahoo.com/mrss/), direkt am Item oder in <media:group> (z.B. YouTube).
+	MediaContents []rawMediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaPlayer   rawMediaPlayer    `xml:"http://search.yahoo.com/mrss/ player"`
+	MediaGroups   []rawMediaGroup   `xml:"http://search.yahoo.com/mrss/ group"`
+
+	MediaTitle       string   `xml:"http://search.yahoo.com/mrss/ title"` // Nur abgefangen, damit sie <title> nicht überschreiben.
+	MediaDescription string   `xml:"http://search.yahoo.com/mrss/ description"`
+	MediaCategories  []string `xml:"http://search.yahoo.com/mrss/ category"`
+}
+
+type rawMediaGroup struct {
+	MediaContents []rawMediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaPlayer   rawMediaPlayer    `xml:"http://search.yahoo.com/mrss/ player"`
+}
+
+type rawMediaContent struct {
+	URL      string         `xml:"url,attr"`
+	Type     string         `xml:"type,attr"`
+	Medium   string         `xml:"medium,attr"`
+	FileSize string         `xml:"fileSize,attr"`
+	Player   rawMediaPlayer `xml:"http://search.yahoo.com/mrss/ player"`
+}
+
+type rawMediaPlayer struct {
+	URL string `xml:"url,attr"`
+}
+
+type rawLink struct { // RSS: Text-Inhalt; Atom: href + rel.
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type rawEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+type rawText struct { // Atom-Textkonstrukt: type="text" | "html" | "xhtml".
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+type rawPerson struct { // Atom-Person: <author><name>…</name></author>.
+	Name string `xml:"name"`
+}
+
+type rawCategory struct { // Atom-Kategorie: term (Pflicht) + optionales label.
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+// ParseFeed liest ein RSS-, RDF- oder Atom-Dokument und liefert die Einträge als Items.
+// Content/Summary sind das Original-HTML des Feeds (nicht bereinigt), PubDate ist der unveränderte Datums-String.
+func ParseFeed(body []byte) ([]Item, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false            // Viele Feeds enthalten HTML-Entities wie &nbsp; außerhalb von CDATA.
+	decoder.Entity = xml.HTMLEntity   // …die so trotzdem aufgelöst werden.
+	decoder.CharsetReader = readLatin // ISO-8859-1/Windows-1252 sind bei älteren Feeds verbreitet.
+
+	var doc rawDocument
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(doc.XMLName.Local) {
+	case "rss":
+		return rssItems(doc.Channel.Items), nil
+	case "rdf":
+		return rssItems(doc.Items), nil
+	case "feed":
+		return atomItems(doc.Entries), nil
+	}
+	return nil, fmt.Errorf("unsupported feed format <%s>", doc.XMLName.Local)
+}
+
+func rssItems(raw []rawItem) []Item {
+	items := make([]Item, 0, len(raw))
+	for _, entry := range raw {
+		item := Item{
+			Title:      entry.Title, // Unverändert: IDs der bestehenden Parser hängen an diesen Strings.
+			Link:       rssLink(entry.Links),
+			PubDate:    entry.PubDate,
+			Content:    entry.Encoded,
+			Summary:    entry.Description,
+			Author:     strings.TrimSpace(entry.Creator),
+			Categories: append(entry.Categories, entry.Subjects...),
+		}
+		if item.Author == "" {
+			item.Author = strings.TrimSpace(entry.Author)
+		}
+		if strings.TrimSpace(item.PubDate) == "" {
+			item.PubDate = entry.Date
+		}
+		for _, enclosure := range entry.Enclosures {
+			item.Enclosures = appendEnclosure(item.Enclosures, enclosure.URL, enclosure.Type, enclosure.Length)
+		}
+		item.Iframe, item.Enclosures = mediaEmbeds(entry.rawMedia, item.Enclosures)
+		items = append(items, item)
+	}
+	return items
+}
+
+func atomItems(raw []rawEntry) []Item {
+	items := make([]Item, 0, len(raw))
+	for _, entry := range raw {
+		item := Item{
+			Title:   html.UnescapeString(stripTags(atomHTML(entry.Title))),
+			PubDate: entry.Published,
+			Content: atomHTML(entry.Content),
+			Summary: atomHTML(entry.Summary),
+		}
+		if strings.TrimSpace(item.PubDate) == "" {
+			item.PubDate = entry.Updated
+		}
+		if len(entry.Authors) > 0 {
+			item.Author = strings.TrimSpace(entry.Authors[0].Name)
+		}
+		for _, link := range entry.Links {
+			switch strings.ToLower(strings.TrimSpace(link.Rel)) {
+			case "", "alternate":
+				if item.Link == "" {
+					item.Link = strings.TrimSpace(link.Href)
+				}
+			case "enclosure":
+				item.Enclosures = appendEnclosure(item.Enclosures, link.Href, link.Type, link.Length)
+			}
+		}
+		for _, category := range entry.Categories {
+			if label := strings.TrimSpace(category.Label); label != "" {
+				item.Categories = append(item.Categories, label)
+			} else {
+				item.Categories = append(item.Categories, category.Term)
+			}
+		}
+		item.Iframe, item.Enclosures = mediaEmbeds(entry.rawMedia, item.Enclosures)
+		items = append(items, item)
+	}
+	return items
+}
+
+func rssLink(links []rawLink) string { // Erster Text-Link; sonst ein Atom-Link mit rel="alternate".
+	for _, link := range links {
+		if strings.TrimSpace(link.Value) != "" {
+			return link.Value
+		}
+	}
+	for _, link := range links {
+		if rel := strings.ToLower(strings.TrimSpace(link.Rel)); (rel == "" || rel == "alternate") && link.Href != "" {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	return ""
+}
+
+func atomHTML(text rawText) string { // Atom-Text als HTML-String.
+	switch strings.ToLower(strings.TrimSpace(text.Type)) {
+	case "html":
+		return text.Text // Chardata ist bereits (entity-decodiertes) HTML.
+	case "xhtml":
+		return unwrapDiv(text.Inner) // Inhalt steckt als XML in einem <div>.
+	}
+	return html.EscapeString(text.Text) // type="text" (Default): reiner Text.
+}
+
+func unwrapDiv(inner string) string { // Entfernt den umschließenden xhtml-<div>.
+	inner = strings.TrimSpace(inner)
+	start := strings.Index(inner, ">")
+	end := strings.LastIndex(inner, "</")
+	if !strings.HasPrefix(inner, "<div") || start == -1 || end <= start {
+		return inner
+	}
+	return strings.TrimSpace(inner[start+1 : end])
+}
+
+func mediaEmbeds(media rawMedia, enclosures []Enclosure) (string, []Enclosure) { // Player-URL als Iframe, Dateien als Enclosures.
+	contents := media.MediaContents
+	players := []rawMediaPlayer{media.MediaPlayer}
+	for _, group := range media.MediaGroups {
+		contents = append(contents, group.MediaContents...)
+		players = append(players, group.MediaPlayer)
+	}
+
+	iframe := ""
+	for _, content := range contents {
+		players = append(players, content.Player)
+		switch strings.ToLower(strings.TrimSpace(content.Type)) {
+		case "text/html": // Eingebettete Seite statt Datei.
+			if iframe == "" {
+				iframe = strings.TrimSpace(content.URL)
+			}
+			continue
+		case "application/x-shockwave-flash": // Alte Flash-Player-URLs (YouTube) sind weder Datei noch einbettbar.
+			continue
+		}
+		enclosures = appendEnclosure(enclosures, content.URL, content.Type, content.FileSize)
+	}
+	for _, player := range players {
+		if url := strings.TrimSpace(player.URL); url != "" {
+			iframe = url // Expliziter Player hat Vorrang.
+			break
+		}
+	}
+	return iframe, enclosures
+}
+
+func appendEnclosure(enclosures []Enclosure, url, mimeType, length string) []Enclosure { // Ohne Duplikate (enclosure + media:content).
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return enclosures
+	}
+	for _, existing := range enclosures {
+		if existing.URL == url {
+			return enclosures
+		}
+	}
+	size, _ := strconv.ParseInt(strings.TrimSpace(length), 10, 64) // Fehlende/kaputte Länge => 0.
+	return append(enclosures, Enclosure{URL: url, Type: strings.TrimSpace(mimeType), Length: size})
+}
+
+func stripTags(value string) string { // Entfernt HTML-Tags (für Titel).
+	return strings.TrimSpace(tagPattern.ReplaceAllString(value, ""))
+}
+
+func readLatin(charset string, input io.Reader) (io.Reader, error) { // CharsetReader für die gängigen 8-Bit-Encodings.
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1", "windows-1252", "cp1252", "us-ascii", "ascii":
+	default:
+		return nil, fmt.Errorf("unsupported charset %q", charset)
+	}
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	converted := make([]byte, 0, len(data))
+	for _, b := range data { // Latin-1-Bytes entsprechen den ersten 256 Unicode-Codepoints.
+		converted = utf8.AppendRune(converted, rune(b))
+	}
+	return bytes.NewReader(converted), nil
+}
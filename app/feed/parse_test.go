@@ -0,0 +1,161 @@
+package feed
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFeed(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []Item
+	}{
+		{
+			name: "rss 2.0 with modules",
+			body: `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel><title>Blog</title>
+<item>
+  <title>WordPress 6.8 &amp; more</title>
+  <link>https://example.org/6-8/</link>
+  <pubDate>Tue, 15 Apr 2026 18:00:00 +0000</pubDate>
+  <dc:creator><![CDATA[ Jane ]]></dc:creator>
+  <category>Releases</category>
+  <description>Short&nbsp;text</description>
+  <content:encoded><![CDATA[<p>Full <strong>text</strong></p>]]></content:encoded>
+</item>
+<item><title>Second</title><link>https://example.org/2/</link><author>mail@example.org (Max)</author></item>
+</channel></rss>`,
+			want: []Item{
+				{Title: "WordPress 6.8 & more", Link: "https://example.org/6-8/", PubDate: "Tue, 15 Apr 2026 18:00:00 +0000", Author: "Jane", Categories: []string{"Releases"}, Summary: "Short\u00a0text", Content: "<p>Full <strong>text</strong></p>"},
+				{Title: "Second", Link: "https://example.org/2/", Author: "mail@example.org (Max)"},
+			},
+		},
+		{
+			name: "rss 1.0 (rdf) with dc:date instead of pubDate",
+			body: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel><title>RDF</title></channel>
+<item><title>Old school</title><link>https://example.org/rdf</link><dc:date>2026-04-01T10:00:00Z</dc:date><dc:subject>News</dc:subject></item>
+</rdf:RDF>`,
+			want: []Item{{Title: "Old school", Link: "https://example.org/rdf", PubDate: "2026-04-01T10:00:00Z", Categories: []string{"News"}}},
+		},
+		{
+			name: "missing pubDate stays empty",
+			body: `<rss version="2.0"><channel><item><title>No date</title><link>https://example.org/x</link></item></channel></rss>`,
+			want: []Item{{Title: "No date", Link: "https://example.org/x"}},
+		},
+		{
+			name: "atom 1.0",
+			body: `<feed xmlns="http://www.w3.org/2005/Atom">
+<title>Atom</title>
+<entry>
+  <title type="html">Hello &lt;em&gt;Atom&lt;/em&gt;</title>
+  <link rel="self" href="https://example.org/self"/>
+  <link href="https://example.org/hello"/>
+  <link rel="enclosure" href="https://example.org/a.mp3" type="audio/mpeg" length="1234"/>
+  <updated>2026-04-02T08:00:00Z</updated>
+  <author><name>Ann</name></author>
+  <category term="news" label="News"/>
+  <category term="wp"/>
+  <summary>a &lt; b</summary>
+  <content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>Body</p></div></content>
+</entry>
+</feed>`,
+			want: []Item{{
+				Title:      "Hello Atom",
+				Link:       "https://example.org/hello",
+				PubDate:    "2026-04-02T08:00:00Z", // Ohne <published> gilt <updated>.
+				Author:     "Ann",
+				Categories: []string{"News", "wp"},
+				Summary:    "a &lt; b",
+				Content:    "<p>Body</p>",
+				Enclosures: []Enclosure{{URL: "https://example.org/a.mp3", Type: "audio/mpeg", Length: 1234}},
+			}},
+		},
+		{
+			name: "enclosures and media embeds",
+			body: `<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/"><channel>
+<item>
+  <title>Talk</title>
+  <enclosure url="https://example.org/talk.mp4" type="video/mp4" length="99"/>
+  <media:content url="https://example.org/talk.mp4" type="video/mp4" fileSize="99"/>
+  <media:content url="https://example.org/thumb.jpg" type="image/jpeg" medium="image"/>
+  <media:content url="https://wordpress.tv/embed/talk" type="text/html"/>
+  <media:title>Media title must not win</media:title>
+</item>
+<item>
+  <title>YouTube</title>
+  <media:group>
+    <media:content url="https://youtube.com/v/x" type="application/x-shockwave-flash"/>
+    <media:player url="https://www.youtube.com/embed/x"/>
+  </media:group>
+</item>
+</channel></rss>`,
+			want: []Item{
+				{
+					Title:  "Talk",
+					Iframe: "https://wordpress.tv/embed/talk",
+					Enclosures: []Enclosure{
+						{URL: "https://example.org/talk.mp4", Type: "video/mp4", Length: 99}, // Doppelt (enclosure + media:content) nur einmal.
+						{URL: "https://example.org/thumb.jpg", Type: "image/jpeg"},
+					},
+				},
+				{Title: "YouTube", Iframe: "https://www.youtube.com/embed/x"},
+			},
+		},
+		{
+			name: "latin-1 body",
+			body: "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><rss version=\"2.0\"><channel><item><title>Gr\xfc\xdfe aus K\xf6ln</title></item></channel></rss>",
+			want: []Item{{Title: "Grüße aus Köln"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFeed([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("ParseFeed: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d items, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if !reflect.DeepEqual(normalizeItem(got[i]), normalizeItem(tt.want[i])) {
+					t.Errorf("item %d:\n got %+v\nwant %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseFeedErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "malformed xml", body: `<rss version="2.0"><channel><item><title>open`, wantErr: "EOF"},
+		{name: "not xml", body: `{"items": []}`, wantErr: "EOF"},
+		{name: "unknown root", body: `<html><body>moved</body></html>`, wantErr: "unsupported feed format <html>"},
+		{name: "unknown charset", body: `<?xml version="1.0" encoding="Shift_JIS"?><rss/>`, wantErr: `unsupported charset "Shift_JIS"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFeed([]byte(tt.body))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseFeed error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func normalizeItem(item Item) Item { // nil und leere Slices gelten als gleich.
+	if len(item.Categories) == 0 {
+		item.Categories = nil
+	}
+	if len(item.Enclosures) == 0 {
+		item.Enclosures = nil
+	}
+	return item
+}
@@ -0,0 +1,217 @@
+package feed // Paket "feed": Parser-Art "wordpress-plugins" – neue Plugin-Versionen aus dem Plugin-Verzeichnis.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"context"       // Abbruch von HTTP- und KI-Calls.
+	"encoding/json" // Antwort der Plugin-Info-API parsen.
+	"errors"        // Fehler einzelner Slugs sammeln.
+	"fmt"           // Fehlertexte, HTML-Bausteine.
+	"html"          // Name/Autor enthalten HTML-Entities.
+	"net/url"       // Query-Parameter bauen.
+	"regexp"        // Überschriften im Changelog finden.
+	"sort"          // Updates nach Datum ordnen.
+	"strings"       // Trimmen, Slugs.
+)
+
+const pluginInfoURL = "https://api.wordpress.org/plugins/info/1.2/" // Plugin-Info-Endpoint; die URL der Quelle kann ihn ersetzen.
+
+const pluginDirectoryURL = "https://wordpress.org/plugins/" // Öffentliche Plugin-Seite: <pluginDirectoryURL><slug>/.
+
+const maxChangelogExcerpt = 4000 // Obergrenze (Bytes) für den Changelog-Auszug, der an die KI geht.
+
+var changelogHeading = regexp.MustCompile(`(?im)<h[1-6][^>]*>|^[ \t]*(?:=+|#{1,6})[ \t]`) // Jede Version beginnt mit einer Überschrift: HTML oder readme-Markup ("= 1.2.3 =", "#### 1.2.3").
+
+type pluginInfo struct { // Ausschnitt aus /plugins/info/1.2/?action=plugin_information.
+	Name        string            `json:"name"`
+	Slug        string            `json:"slug"`
+	Version     string            `json:"version"`
+	Author      string            `json:"author"`       // HTML-Link auf den Autor.
+	Tested      string            `json:"tested"`       // "Tested up to"-Version aus dem readme.
+	Requires    string            `json:"requires"`     // Mindestversion WordPress.
+	LastUpdated string            `json:"last_updated"` // "2025-04-30 3:07pm GMT".
+	Sections    map[string]string `json:"sections"`     // description, changelog, … als HTML.
+	Error       string            `json:"error"`        // Gesetzt, wenn der Slug unbekannt ist.
+}
+
+// LatestPluginUpdates ist die Parser-Art "wordpress-plugins": fragt für jeden konfigurierten Slug die aktuelle
+// Version ab und liefert ein Item pro Version, die noch nicht gespeichert ist (GUID "slug@version").
+// Fehler einzelner Slugs werden gesammelt zurückgegeben; die Items der übrigen Slugs bleiben erhalten.
+func LatestPluginUpdates(ctx context.Context, src Source, fetch Fetcher) ([]Item, error) {
+	if len(src.Slugs) == 0 {
+		return nil, fmt.Errorf("%s: kind %q needs slugs", src.label(KindPlugins), KindPlugins)
+	}
+
+	var items []Item
+	var errs []error
+	for _, slug := range src.Slugs { // Erst alle Slugs abfragen; das Limit greift nach dem Sortieren.
+		if err := ctx.Err(); err != nil { // Abgebrochen: keine weiteren Requests/KI-Calls.
+			return nil, err
+		}
+
+		info, err := fetchPluginInfo(ctx, src, slug, fetch)
+		if errors.Is(err, ErrNotModified) { // Antwort unverändert: keine neue Version.
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		item := info.item()
+		if item.GUID == "" || src.seen(item.GUID) { // Version schon gespeichert: kein KI-Call.
+			continue
+		}
+		items = append(items, item)
+	}
+
+	sortByPubDate(items)
+	if len(items) > src.limit() { // Die neuesten Updates gewinnen, nicht die ersten Slugs der Liste.
+		items = items[:src.limit()]
+	}
+	for i := range items { // KI-Calls nur für die Items, die in den Feed kommen.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		items[i].Content = buildReleasesContent(ctx, src, PromptPlugin, PromptData{
+			Title:      items[i].Title,
+			Link:       items[i].Link,
+			Categories: items[i].Categories,
+			Body:       items[i].Summary,
+		})
+	}
+	return items, errors.Join(errs...)
+}
+
+func sortByPubDate(items []Item) { // Neueste zuerst; Items ohne lesbares Datum ans Ende.
+	sort.SliceStable(items, func(i, j int) bool {
+		a, errA := ParsePubDate(items[i].PubDate)
+		b, errB := ParsePubDate(items[j].PubDate)
+		if errA != nil || errB != nil {
+			return errA == nil && errB != nil
+		}
+		return a.After(b)
+	})
+}
+
+func fetchPluginInfo(ctx context.Context, src Source, slug string, fetch Fetcher) (pluginInfo, error) { // Ein Slug → Plugin-Info.
+	label := fmt.Sprintf("%s (%s)", src.label(KindPlugins), slug)
+	endpoint, err := pluginInfoEndpoint(src.feedURL(pluginInfoURL), slug)
+	if err != nil {
+		return pluginInfo{}, fmt.Errorf("%s: %w", label, err)
+	}
+
+	body, err := fetch(ctx, endpoint, label)
+	if err != nil {
+		return pluginInfo{}, err
+	}
+	var info pluginInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return pluginInfo{}, fmt.Errorf("%s: %w", label, err)
+	}
+	if info.Error != "" {
+		return pluginInfo{}, fmt.Errorf("%s: %s", label, info.Error)
+	}
+	if info.Slug == "" {
+		info.Slug = slug
+	}
+	return info, nil
+}
+
+func pluginInfoEndpoint(base, slug string) (string, error) { // Baut ?action=plugin_information&request[slug]=….
+	endpoint, err := url.Parse(strings.TrimSpace(base))
+	if err != nil || endpoint.Host == "" {
+		return "", fmt.Errorf("invalid url %q", base)
+	}
+	query := endpoint.Query()
+	query.Set("action", "plugin_information")
+	query.Set("request[slug]", slug)
+	query.Set("request[fields][sections]", "1") // Changelog steckt in den Sections.
+	endpoint.RawQuery = query.Encode()
+	return endpoint.String(), nil
+}
+
+func (p pluginInfo) item() Item { // Plugin-Info → Item; Summary ist das HTML, aus dem der Content gebaut wird.
+	name := strings.TrimSpace(html.UnescapeString(stripTags(p.Name)))
+	if name == "" {
+		name = p.Slug
+	}
+	version := strings.TrimSpace(p.Version)
+	if version == "" { // Ohne Version keine Änderung erkennbar.
+		return Item{}
+	}
+	return Item{
+		Title:   name + " " + version,
+		Link:    pluginDirectoryURL + url.PathEscape(p.Slug) + "/",
+		PubDate: strings.TrimSpace(p.LastUpdated),
+		Summary: p.summary(name, version),
+		Author:  strings.TrimSpace(html.UnescapeString(stripTags(p.Author))),
+		GUID:    p.Slug + "@" + version,
+	}
+}
+
+func (p pluginInfo) summary(name, version string) string { // Kopfzeile, "Tested up to" und Changelog-Auszug als HTML.
+	var out strings.Builder
+	fmt.Fprintf(&out, "<p><strong>%s %s</strong></p>", html.EscapeString(name), html.EscapeString(version))
+	if tested := strings.TrimSpace(p.Tested); tested != "" {
+		fmt.Fprintf(&out, "<p>Tested up to WordPress %s.</p>", html.EscapeString(tested))
+	}
+	out.WriteString(changelogExcerpt(p.Sections["changelog"], version))
+	return out.String()
+}
+
+func changelogExcerpt(changelog, version string) string { // Abschnitt der aktuellen Version aus dem Changelog.
+	changelog = strings.TrimSpace(changelog)
+	headings := changelogHeading.FindAllStringIndex(changelog, -1)
+	if len(headings) > 0 {
+		section := func(i int) (int, int) { // Von der Überschrift i bis zur nächsten.
+			if i+1 < len(headings) {
+				return headings[i][0], headings[i+1][0]
+			}
+			return headings[i][0], len(changelog)
+		}
+		start, end := section(0) // Ohne passende Überschrift: der oberste Abschnitt (neueste Version).
+		for i := range headings {
+			if from, to := section(i); headingMentions(changelog[from:to], version) {
+				start, end = from, to
+				break
+			}
+		}
+		changelog = dropHeading(changelog[start:end]) // Version steht schon in der Kopfzeile.
+	}
+	if len(changelog) > maxChangelogExcerpt { // Nach einem Listenpunkt abschneiden, damit kein Tag halbiert wird.
+		cut := strings.LastIndex(changelog[:maxChangelogExcerpt], "</li>")
+		if cut < 0 {
+			return ""
+		}
+		changelog = changelog[:cut+len("</li>")] // Offene Tags schließt SanitizeHTML.
+	}
+	return changelog
+}
+
+func headingMentions(section, version string) bool { // Nennt die Überschrift des Abschnitts genau diese Version?
+	heading, _ := splitHeading(section)
+	for _, word := range strings.Fields(stripTags(heading)) { // "1.2" darf nicht auf "1.2.3" passen.
+		if strings.Trim(word, "=#:-()[]vV") == version {
+			return true
+		}
+	}
+	return false
+}
+
+func dropHeading(section string) string { // Entfernt die Überschrift am Anfang eines Abschnitts.
+	_, rest := splitHeading(section)
+	return rest
+}
+
+func splitHeading(section string) (heading, rest string) { // Überschrift am Anfang eines Abschnitts und der Rest.
+	if !strings.HasPrefix(section, "<") { // readme-Markup: Die Überschrift ist die erste Zeile.
+		heading, rest, _ = strings.Cut(section, "\n")
+		return heading, strings.TrimSpace(rest)
+	}
+	end := strings.Index(strings.ToLower(section), "</h")
+	if end < 0 { // Nicht geschlossen: alles zählt als Überschrift, nichts wird entfernt.
+		return section, section
+	}
+	if closing := strings.IndexByte(section[end:], '>'); closing >= 0 {
+		return section[:end], strings.TrimSpace(section[end+closing+1:])
+	}
+	return section[:end], section
+}
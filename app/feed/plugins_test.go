@@ -0,0 +1,152 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"wapuugotchi/feed/app/ai"
+)
+
+func TestChangelogExcerpt(t *testing.T) {
+	tests := []struct {
+		name      string
+		changelog string
+		version   string
+		want      string
+	}{
+		{
+			name:      "rendered html",
+			changelog: "<h4>1.3.0</h4><ul><li>New</li></ul><h4>1.2.3</h4><ul><li>Fix</li></ul>",
+			version:   "1.2.3",
+			want:      "<ul><li>Fix</li></ul>",
+		},
+		{
+			name:      "readme markup with equals signs",
+			changelog: "= 1.3.0 =\n* New\n\n= 1.2.3 =\n* Fix\n",
+			version:   "1.2.3",
+			want:      "* Fix",
+		},
+		{
+			name:      "markdown heading",
+			changelog: "#### 1.3.0\n* New\n#### 1.2.3 (2026-04-01)\n* Fix",
+			version:   "1.2.3",
+			want:      "* Fix",
+		},
+		{
+			name:      "1.2.30 is not 1.2.3",
+			changelog: "<h4>1.2.30</h4><ul><li>Later</li></ul><h4>1.2.3</h4><ul><li>Fix</li></ul>",
+			version:   "1.2.3",
+			want:      "<ul><li>Fix</li></ul>",
+		},
+		{
+			name:      "1.2.3 is not 1.2.30",
+			changelog: "<h4>1.2.3</h4><ul><li>Fix</li></ul>",
+			version:   "1.2.30",
+			want:      "<ul><li>Fix</li></ul>", // Kein Treffer: oberster Abschnitt.
+		},
+		{
+			name:      "missing section falls back to top",
+			changelog: "<h4>2.0.0</h4><ul><li>Top</li></ul><h4>1.9.0</h4><ul><li>Old</li></ul>",
+			version:   "2.0.1",
+			want:      "<ul><li>Top</li></ul>",
+		},
+		{
+			name:      "no headings",
+			changelog: " <ul><li>Only list</li></ul> ",
+			version:   "1.0",
+			want:      "<ul><li>Only list</li></ul>",
+		},
+		{
+			name:      "empty",
+			changelog: "",
+			version:   "1.0",
+			want:      "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := changelogExcerpt(tt.changelog, tt.version); got != tt.want {
+				t.Errorf("changelogExcerpt(%q, %q) = %q, want %q", tt.changelog, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadingMentions(t *testing.T) {
+	tests := []struct {
+		section string
+		version string
+		want    bool
+	}{
+		{section: "<h4>1.2.3</h4><ul><li>1.2.30</li></ul>", version: "1.2.3", want: true},
+		{section: "<h4>Version 1.2.3 (2026-04-01)</h4>", version: "1.2.3", want: true},
+		{section: "<h4>v1.2.3:</h4>", version: "1.2.3", want: true},
+		{section: "= 1.2.3 =\n* Fix", version: "1.2.3", want: true},
+		{section: "#### [1.2.3] - 2026-04-01\n* Fix", version: "1.2.3", want: true},
+		{section: "<h4>1.2.30</h4>", version: "1.2.3", want: false},
+		{section: "<h4>1.2</h4>", version: "1.2.3", want: false},
+		{section: "<h4>1.3.0</h4><ul><li>Backports 1.2.3</li></ul>", version: "1.2.3", want: false}, // Nur die Überschrift zählt.
+		{section: "= 1.3.0 =\n* Backports 1.2.3", version: "1.2.3", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.section, func(t *testing.T) {
+			if got := headingMentions(tt.section, tt.version); got != tt.want {
+				t.Errorf("headingMentions(%q, %q) = %v, want %v", tt.section, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+type countingBackend struct{ calls atomic.Int32 }
+
+func (b *countingBackend) Name() string  { return "counting" }
+func (b *countingBackend) Model() string { return "" }
+func (b *countingBackend) Complete(context.Context, string) (string, error) {
+	b.calls.Add(1)
+	return "<p><strong>Plugin 1.0 is here!</strong></p><p>A small update.</p><ul><li><strong>Fix:</strong> Works.</li><li><strong>Docs:</strong> Updated.</li></ul>", nil
+}
+
+func TestLatestPluginUpdatesLimitsAfterSorting(t *testing.T) {
+	backend := &countingBackend{}
+	ai.SetBackend(backend)
+	t.Cleanup(func() { ai.SetBackend(nil) })
+
+	updated := map[string]string{ // Slug → last_updated; Slug-Reihenfolge ist nicht die Datumsreihenfolge.
+		"alpha": "2026-01-05 9:00am GMT",
+		"beta":  "2026-04-01 3:07pm GMT",
+		"gamma": "2026-03-10 11:30am GMT",
+		"delta": "2026-04-02 8:00am GMT",
+	}
+	fetch := func(_ context.Context, endpoint, _ string) ([]byte, error) {
+		parsed, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		slug := parsed.Query().Get("request[slug]")
+		return json.Marshal(pluginInfo{Name: slug, Slug: slug, Version: "1.0", LastUpdated: updated[slug]})
+	}
+	src := Source{
+		MaxItems: 2,
+		Slugs:    []string{"alpha", "beta", "gamma", "delta"},
+		Seen:     func(guid string) bool { return guid == "delta@1.0" },
+	}
+
+	items, err := LatestPluginUpdates(context.Background(), src, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, item := range items {
+		got = append(got, item.GUID)
+	}
+	if want := []string{"beta@1.0", "gamma@1.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+	if calls := backend.calls.Load(); calls != 2 {
+		t.Errorf("ai calls = %d, want 2 (only for kept items)", calls)
+	}
+}
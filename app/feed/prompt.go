@@ -0,0 +1,106 @@
+package feed // Paket "feed": Prompt-Templates aus prompts/*.tmpl mit eingebautem Fallback.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"bytes"         // Template-Ausgabe puffern.
+	"fmt"           // Fehlertexte mit Dateiname/Prompt-Name.
+	"os"            // prompts/-Verzeichnis lesen.
+	"path/filepath" // Dateinamen → Prompt-Namen.
+	"strings"       // Endungen prüfen, Trimmen.
+	"text/template" // Templates mit benannten Feldern statt %s.
+
+	"wapuugotchi/feed/prompts" // Eingebettete prompts/*.tmpl als Fallback.
+)
+
+const ( // Namen der eingebauten Prompts (= Dateiname ohne .tmpl in prompts/).
+	PromptReleases = "releases"
+	PromptBlog     = "blog"
+	PromptPlugin   = "plugin"
+)
+
+const promptExtension = ".tmpl" // Nur Dateien mit dieser Endung werden als Prompt geladen.
+
+// PromptData sind die Felder, die ein Prompt-Template verwenden kann ({{.Title}}, {{.Body}}, …).
+type PromptData struct {
+	Title      string   // Titel des Items.
+	Link       string   // Link zum Original.
+	Categories []string // Kategorien des Items.
+	Body       string   // Eigentlicher Text (Description/Content), den die KI verarbeiten soll.
+	Locale     string   // Zielsprache, z.B. "en" oder "de".
+}
+
+// Prompts bildet Prompt-Namen auf geparste Templates ab.
+type Prompts map[string]*template.Template
+
+var builtinPrompts = []string{PromptReleases, PromptBlog, PromptPlugin} // Eingebettet; Fallback, wenn prompts/ fehlt oder eine Datei nicht existiert.
+
+// LoadPrompts lädt die eingebauten Prompts und überschreibt sie mit prompts/<name>.tmpl (falls vorhanden).
+func LoadPrompts(dir string) (Prompts, error) {
+	prompts := make(Prompts, len(builtinPrompts))
+	for _, name := range builtinPrompts {
+		tmpl, err := builtinPrompt(name)
+		if err != nil {
+			return nil, err
+		}
+		prompts[name] = tmpl
+	}
+
+	list, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) { // prompts/ ist optional.
+			return prompts, nil
+		}
+		return nil, err
+	}
+	for _, file := range list {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), promptExtension) {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(file.Name(), promptExtension)
+		tmpl, err := parsePrompt(name, string(data))
+		if err != nil { // Kaputte Templates früh melden statt zur Laufzeit still auf Fallback zu gehen.
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		prompts[name] = tmpl
+	}
+	return prompts, nil
+}
+
+// Resolve liefert ein Template per Name; unbekannte Werte werden als Inline-Template geparst.
+func (p Prompts) Resolve(value string) (*template.Template, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil // Kein Prompt konfiguriert: der Parser nimmt seinen Default.
+	}
+	if tmpl, ok := p[value]; ok {
+		return tmpl, nil
+	}
+	if !strings.ContainsAny(value, " \n{") { // Sieht aus wie ein Name, nicht wie ein Text: vermutlich Tippfehler.
+		return nil, fmt.Errorf("unknown prompt %q", value)
+	}
+	return parsePrompt("inline", value)
+}
+
+func builtinPrompt(name string) (*template.Template, error) { // Eingebettetes prompts/<name>.tmpl als Template.
+	data, err := prompts.Files.ReadFile(name + promptExtension)
+	if err != nil {
+		return nil, fmt.Errorf("unknown builtin prompt %q", name)
+	}
+	return parsePrompt(name, string(data))
+}
+
+func parsePrompt(name, text string) (*template.Template, error) { // Parst ein Template; fehlende Felder sind Fehler.
+	return template.New(name).Option("missingkey=error").Parse(strings.TrimSpace(text))
+}
+
+func renderPrompt(tmpl *template.Template, data PromptData) (string, error) { // Führt ein Template mit den Item-Daten aus.
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("prompt %s: %w", tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,47 @@
+package feed
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinPrompts(t *testing.T) {
+	for _, name := range builtinPrompts {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := builtinPrompt(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := renderPrompt(tmpl, PromptData{Body: "BODY"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasSuffix(got, "\n\nBODY") || strings.Contains(got, "{{") || strings.Contains(got, "%s") {
+				t.Errorf("rendered prompt = %q, want text ending in the body", got)
+			}
+		})
+	}
+	if _, err := builtinPrompt("missing"); err == nil {
+		t.Error("builtinPrompt(missing): want error")
+	}
+}
+
+func TestLoadPromptsOverridesBuiltin(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, PromptBlog+promptExtension), []byte("Custom {{.Locale}}: {{.Body}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadPrompts(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := renderPrompt(loaded[PromptBlog], PromptData{Body: "text", Locale: "de"})
+	if err != nil || got != "Custom de: text" {
+		t.Errorf("blog prompt = %q, %v; want override from prompts/", got, err)
+	}
+	if loaded[PromptReleases] == nil || loaded[PromptPlugin] == nil {
+		t.Error("builtin prompts missing next to the override")
+	}
+}
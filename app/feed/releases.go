@@ -1,91 +1,82 @@
 package feed // Definiert das Paket "feed"; enthält Logik zum Abrufen/Transformieren von RSS-Feed-Inhalten.
 
 import ( // Import-Block: Abhängigkeiten dieser Datei.
-	"encoding/xml" // Wird gebraucht, um RSS-XML (WordPress Releases Feed) in Go-Structs zu unmarshallen.
-	"strings"      // Wird verwendet, um Whitespace zu trimmen und leere Inhalte sauber zu erkennen.
+	"context" // Abbruch von HTTP- und KI-Calls.
+	"fmt"     // Baut den Korrektur-Prompt für den Retry, loggt Template-Fehler.
+	"os"      // Template-Fehler auf Stderr.
+	"strings" // Wird verwendet, um Whitespace zu trimmen und leere Inhalte sauber zu erkennen.
 
 	"wapuugotchi/feed/app/ai" // Eigenes KI-Paket: transformiert Rohtext mit einem Prompt in gewünschtes Ausgabeformat.
 )
 
-const releasesFeedURL = "https://wordpress.org/news/category/releases/feed/"
 // URL des WordPress.org News-Releases RSS-Feeds; hier kommen neue Release-Posts her.
+const releasesFeedURL = "https://wordpress.org/news/category/releases/feed/"
 
-const releasesPattern = "You are given a WordPress release announcement. Extract the version, a one-sentence summary, and 2-4 key highlights written for a WordPress site administrator.\n\nImportant: If the release is a Release Candidate (RC), Beta, or any pre-release, always include the full label in the headline (e.g. \"WordPress 7.0 RC2 is here!\" not \"WordPress 7.0 is here!\").\n\nOutput raw HTML on a single line. No markdown, no code blocks, no extra text. Use literal < and > characters.\n\nFollow this structure exactly:\n<p><strong>WordPress 6.5 is here!</strong></p><p>A major release packed with new features and improvements.</p><ul><li><strong>Block Bindings API:</strong> Connect blocks directly to custom data sources.</li><li><strong>Font Library:</strong> Install and manage fonts from the editor.</li></ul>\n\nNow do the same for this text:\n\n%s"
-// Prompt-Template: nutzt ein konkretes Beispiel statt abstrakter Platzhalter-Syntax.
-// und du vermutlich wirklich HTML im RSS <description> ausliefern willst, nicht escaped Entities.
+// Korrektur-Prompt für den einen Retry: Original-Prompt, ungültige Antwort, Grund (aus ValidateReleaseHTML).
+const correctionPattern = "%s\n\nYour previous answer was:\n\n%s\n\nIt is invalid: %v. Answer again with raw HTML on a single line that follows the structure exactly."
 
 type Item struct { // Internes, vereinheitlichtes Item-Format für dein Aggregationssystem (wird von mehreren Quellen genutzt).
-	Title      string   // Titel der Nachricht (z.B. "WordPress 6.x released").
-	Link       string   // Link zur Originalquelle.
-	PubDate    string   // Veröffentlichungsdatum als String (RSS-Format), später anderswo geparsed/normalisiert.
-	Content    string   // Inhalt/Description, hier typischerweise HTML (entweder KI-rendered oder Fallback-Text).
-	Categories []string // Kategorien/Tags aus dem Feed (optional).
-}
-
-type wordPressFeed struct { // Repräsentiert das Root-Level des RSS-Dokuments (vereinfacht auf das, was du brauchst).
-	Channel wordPressChannel `xml:"channel"` // Mappt das <channel>-Element auf dieses Feld.
+	Title      string      // Titel der Nachricht (z.B. "WordPress 6.x released").
+	Link       string      // Link zur Originalquelle.
+	PubDate    string      // Veröffentlichungsdatum als String (RSS-Format), später anderswo geparsed/normalisiert.
+	Content    string      // Inhalt/Description, hier typischerweise HTML (entweder KI-rendered oder Fallback-Text).
+	Summary    string      // Kurzfassung aus dem Feed (RSS description / Atom summary); nur für die Parser.
+	GUID       string      // Stabile Kennung aus der Quelle (z.B. "slug@version"); wenn gesetzt, Basis der Entry-ID.
+	Author     string      // Autor (dc:creator, Atom author, REST _embedded.author); optional.
+	Categories []string    // Kategorien/Tags aus dem Feed (optional).
+	Iframe     string      // Optionales Embed (media:player bzw. media:content als text/html).
+	Enclosures []Enclosure // Angehängte Dateien (Audio, Video, Bilder).
 }
 
-type wordPressChannel struct { // Repräsentiert <channel>, in dem die <item>-Elemente liegen.
-	Items []wordPressItem `xml:"item"` // Alle einzelnen Feed-Items (Posts) aus dem RSS.
-}
-
-type wordPressItem struct { // Repräsentiert ein einzelnes <item> im WordPress Releases Feed.
-	Title       string   `xml:"title"`       // Mappt <title> → Titel des Posts.
-	Link        string   `xml:"link"`        // Mappt <link> → URL zum Post.
-	PubDate     string   `xml:"pubDate"`     // Mappt <pubDate> → Veröffentlichungsdatum (RSS-String).
-	Description string   `xml:"description"` // Mappt <description> → Inhalt (oft HTML/CDATAsnippet).
-	Categories  []string `xml:"category"`    // Mappt <category> (mehrfach) → Slice von Kategorien/Tags.
-}
-
-func LatestReleases(fetch func(url, source string) ([]byte, error)) (Item, error) {
-	// Exportierte Funktion: holt den neuesten WordPress Release-Post und gibt ihn als internes Item zurück.
+func LatestReleases(ctx context.Context, src Source, fetch Fetcher) ([]Item, error) {
+	// Exportierte Funktion (Parser-Art "wordpress-releases"): holt die neuesten WordPress Release-Posts als interne Items.
 	// fetch wird injiziert (Dependency Injection), damit HTTP-Handling/Retry/Headers zentral bleibt und testbar ist.
 
-	body, err := fetch(releasesFeedURL, "wordpress releases")
-	// Ruft den Feed per HTTP ab; "wordpress releases" dient typischerweise für Fehlermeldungen/Logging im fetch.
+	body, err := fetch(ctx, src.feedURL(releasesFeedURL), src.label("wordpress releases"))
+	// Ruft den Feed per HTTP ab; URL und Label kommen aus der Provider-Konfiguration (mit Defaults).
 
 	if err != nil {
 		// Wenn HTTP-Fetch scheitert (Timeout, non-2xx, Netzwerk)…
-		return Item{}, err
+		return nil, err
 		// …weiterreichen: hier kann man ohne Body nichts sinnvoll machen.
 	}
 
-	var feed wordPressFeed
-	// Zielvariable für das XML-Unmarshal: danach steht feed.Channel.Items gefüllt da (oder leer).
+	parsed, err := ParseFeed(body)
+	// Parst das RSS-XML mit dem generischen Feed-Parser (RSS/RDF/Atom).
 
-	if err := xml.Unmarshal(body, &feed); err != nil {
-		// Parst das RSS-XML in die Structs; scheitert bei ungültigem XML oder Strukturabweichungen.
-		return Item{}, err
-		// Fehler weitergeben: ohne valide Struktur weißt du nicht, was "latest" ist.
+	if err != nil {
+		// Ungültiges XML oder unbekanntes Format: ohne Items weißt du nicht, was "latest" ist.
+		return nil, err
 	}
 
-	if len(feed.Channel.Items) == 0 {
-		// Falls der Feed keine Items enthält (z.B. temporär leer oder parse lieferte nichts)…
-		return Item{}, nil
-		// …kein Fehler: bedeutet schlicht "kein neuer Content verfügbar".
+	items := make([]Item, 0, src.limit())
+	for _, item := range src.fresh(parsed) {
+		// Nur neue Items (neuer als der letzte Entry dieser Quelle) bis zur Obergrenze: kein KI-Call für Bekanntes.
+		if err := ctx.Err(); err != nil {
+			// Abgebrochen (Ctrl-C, Deadline): keine weiteren KI-Calls, halbe Ergebnisse verwerfen.
+			return nil, err
+		}
+
+		item.Content = buildReleasesContent(ctx, src, PromptReleases, PromptData{
+			Title:      item.Title,
+			Link:       item.Link,
+			Categories: item.Categories,
+			Body:       item.Summary,
+		})
+		// Baut den Content aus der Description: entweder KI-formatiertes RAW-HTML oder Fallback auf Original-Description.
+
+		items = append(items, item)
 	}
 
-	item := feed.Channel.Items[0]
-	// Nimmt das erste Item als "latest"; setzt voraus, dass der RSS-Feed absteigend sortiert ist (üblich bei RSS).
-
-	content := buildReleasesContent(item.Description)
-	// Baut den Content: entweder KI-formatiertes RAW-HTML oder Fallback auf Original-Description.
-
-	return Item{
-		Title:      item.Title,      // Übernimmt Titel aus dem Feed.
-		Link:       item.Link,       // Übernimmt Link aus dem Feed.
-		PubDate:    item.PubDate,    // Übernimmt PubDate-String unverändert (wird später normalisiert).
-		Content:    content,         // Setzt erzeugten Content (KI oder Fallback).
-		Categories: item.Categories, // Übernimmt Kategorien aus dem Feed.
-	}, nil
-	// Erfolgreiche Rückgabe: ein "standardisiertes" Item für den Aggregator.
+	return items, nil
+	// Erfolgreiche Rückgabe: "standardisierte" Items für den Aggregator (leer, wenn der Feed leer ist).
 }
 
-func buildReleasesContent(description string) string {
+func buildReleasesContent(ctx context.Context, src Source, fallbackPrompt string, data PromptData) string {
 	// Hilfsfunktion: verarbeitet den description-Text (typisch HTML) und versucht per KI ein strikt formatiertes HTML zu erzeugen.
+	// fallbackPrompt ist der Default-Prompt des Parsers (releases, plugin), falls die Quelle keinen eigenen hat.
 
-	content := strings.TrimSpace(description)
+	content := strings.TrimSpace(data.Body)
 	// Trim: verhindert, dass Whitespace-only Descriptions als "Content vorhanden" zählen.
 
 	if content == "" {
@@ -94,15 +85,50 @@ func buildReleasesContent(description string) string {
 		// …liefer leer zurück: upstream kann dann Entry ggf. droppen oder minimal ausgeben.
 	}
 
-	rendered, err := ai.TransformText(releasesPattern, content)
-	// Übergibt den Rohtext an die KI mit einem sehr strikten Prompt (RAW HTML, genaues Format, einzeilig).
+	fallback := SanitizeHTML(content)
+	// Fallback: Original-Description, ebenfalls auf die Allowlist reduziert (auch Upstream-HTML wird nicht blind übernommen).
+
+	data.Body = content
+	prompt, err := src.renderPrompt(fallbackPrompt, data)
+	// Rendert das Prompt-Template (z.B. prompts/releases.tmpl oder eingebauter Fallback) mit den Item-Feldern.
+
+	if err != nil {
+		// Kaputtes Template: ohne Prompt kein KI-Call, also Fallback auf die bereinigte Original-Description.
+		fmt.Fprintf(os.Stderr, "%s: prompt: %v (using original description)\n", src.label(fallbackPrompt), err)
+		// Loggen, damit ein Tippfehler im Template nicht unbemerkt jeden Eintrag auf den Fallback setzt.
+		return fallback
+	}
+
+	rendered, err := ai.Complete(ctx, prompt)
+	// Übergibt den fertigen Prompt an die KI (sehr strikt: RAW HTML, genaues Format, einzeilig).
 
 	if err != nil {
 		// Wenn die KI scheitert (Netzwerk, Rate Limit, Parsing, Modellfehler)…
-		return content
+		return fallback
 		// …Fallback: lieber Original-Description als gar nichts, damit der Feed nicht leer wird.
 	}
 
-	return rendered
-	// Erfolgsfall: KI-generiertes RAW-HTML zurückgeben (entspricht dem gewünschten Layout).
+	cleaned := SanitizeHTML(rendered)
+	// KI-Ausgabe nie blind übernehmen: Allowlist, Code-Fences raus, offene Tags schließen.
+
+	problem := ValidateReleaseHTML(cleaned)
+	if problem == nil {
+		return cleaned
+		// Erfolgsfall: KI-generiertes HTML in der erwarteten Struktur.
+	}
+
+	rendered, err = ai.Complete(ctx, fmt.Sprintf(correctionPattern, prompt, rendered, problem))
+	// Genau ein zweiter Versuch: gleicher Prompt + die ungültige Antwort + was daran falsch war.
+
+	if err != nil {
+		return fallback
+	}
+
+	cleaned = SanitizeHTML(rendered)
+	if ValidateReleaseHTML(cleaned) != nil {
+		// Auch der zweite Versuch passt nicht: lieber die Original-Description als kaputte Struktur.
+		return fallback
+	}
+
+	return cleaned
 }
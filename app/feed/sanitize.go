@@ -0,0 +1,152 @@
+package feed // Paket "feed": Sanitizer und Validator für KI-generiertes HTML.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"fmt"     // Fehlertexte des Validators.
+	"html"    // Text-Teile normalisiert escapen.
+	"net/url" // href-Werte prüfen (nur http/https/mailto).
+	"regexp"  // Tags, Code-Fences und Attribute finden.
+	"strings" // Trimmen, Zusammenbauen.
+)
+
+var allowedTags = map[string]bool{ // Allowlist: alles andere wird entfernt (Text bleibt erhalten).
+	"p":      true,
+	"strong": true,
+	"em":     true,
+	"ul":     true,
+	"li":     true,
+	"a":      true,
+}
+
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true} // Erlaubte Schemes für a[href].
+
+var ( // Vorcompilierte Regexe für SanitizeHTML/ValidateReleaseHTML.
+	codeFencePattern   = regexp.MustCompile("(?m)^\\s*```[a-zA-Z]*\\s*$")                                                                                                          // Markdown-Code-Fences (```html …```).
+	dropBlockPattern   = regexp.MustCompile(`(?is)<script\b.*?</script\s*>|<style\b.*?</style\s*>|<iframe\b.*?</iframe\s*>|<object\b.*?</object\s*>|<template\b.*?</template\s*>`) // Gefährliche Blöcke inkl. Inhalt.
+	commentPattern     = regexp.MustCompile(`(?s)<!--.*?-->`)                                                                                                                      // HTML-Kommentare.
+	tagPattern         = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)\b([^<>]*)>`)                                                                                              // Öffnende/schließende Tags.
+	hrefPattern        = regexp.MustCompile(`(?i)\shref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^'"\s>]+))`)                                                                                // href-Attribut in allen Quote-Varianten.
+	releaseShape       = regexp.MustCompile(`^<p><strong>[^<]+</strong></p><p>(?:[^<]|` + inlineTag + `)+</p><ul>(?:<li>(?:[^<]|` + inlineTag + `)+</li>){2,4}</ul>$`)
+	releaseListPattern = regexp.MustCompile(`<li>`)                                  // Zählt Highlights für die Fehlermeldung.
+	blockGapPattern    = regexp.MustCompile(`(</?(?:p|ul|li)>)\s+(</?(?:p|ul|li)>)`) // Whitespace zwischen Block-Tags.
+)
+
+const inlineTag = `(?:<(?:strong|em)>[^<]*</(?:strong|em)>|<a href="[^"]*">[^<]*</a>)` // Inline-Formatierung und Links innerhalb von Absätzen/Listenpunkten (wie die Allowlist).
+
+// SanitizeHTML reduziert HTML auf die Allowlist (p, strong, em, ul, li, a[href]) und schließt offene Tags.
+func SanitizeHTML(input string) string {
+	input = codeFencePattern.ReplaceAllString(input, "") // Modelle verpacken HTML gern in ```html … ```.
+	input = dropBlockPattern.ReplaceAllString(input, "")
+	input = commentPattern.ReplaceAllString(input, "")
+
+	var out strings.Builder
+	var stack []string // Offene erlaubte Tags, um am Ende/bei falscher Verschachtelung sauber zu schließen.
+	last := 0
+	for _, match := range tagPattern.FindAllStringSubmatchIndex(input, -1) {
+		out.WriteString(escapeText(input[last:match[0]]))
+		last = match[1]
+
+		closing := input[match[2]:match[3]] == "/"
+		name := strings.ToLower(input[match[4]:match[5]])
+		attrs := input[match[6]:match[7]]
+		if !allowedTags[name] {
+			continue // Tag verwerfen, Text drumherum bleibt.
+		}
+
+		if closing {
+			stack = closeTag(&out, stack, name)
+			continue
+		}
+		switch name {
+		case "p", "ul":
+			stack = closeTag(&out, stack, "p") // Block-Elemente beenden einen offenen Absatz.
+		case "li":
+			if !contains(stack, "ul") { // Listenpunkt ohne Liste: Tag verwerfen.
+				continue
+			}
+			stack = closeTag(&out, stack, "li") // <li>a<li>b → zwei getrennte Punkte.
+		}
+		if name == "a" {
+			href, ok := safeHref(attrs)
+			if !ok { // Link ohne (sicheres) Ziel: nur den Text behalten.
+				continue
+			}
+			out.WriteString(`<a href="` + html.EscapeString(href) + `">`)
+		} else {
+			out.WriteString("<" + name + ">")
+		}
+		stack = append(stack, name)
+	}
+	out.WriteString(escapeText(input[last:]))
+	for i := len(stack) - 1; i >= 0; i-- { // Übrig gebliebene Tags schließen.
+		out.WriteString("</" + stack[i] + ">")
+	}
+	result := out.String()
+	for { // Mehrzeilige Antworten auf eine Zeile bringen; wiederholen, weil sich Treffer überlappen können.
+		collapsed := blockGapPattern.ReplaceAllString(result, "$1$2")
+		if collapsed == result {
+			break
+		}
+		result = collapsed
+	}
+	return strings.TrimSpace(result)
+}
+
+// ValidateReleaseHTML prüft die erwartete Release-Struktur: Überschrift, Zusammenfassung, 2-4 Highlights.
+func ValidateReleaseHTML(content string) error {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return fmt.Errorf("empty response")
+	}
+	if releaseShape.MatchString(content) {
+		return nil
+	}
+	if count := len(releaseListPattern.FindAllString(content, -1)); count < 2 || count > 4 {
+		return fmt.Errorf("expected 2-4 <li> highlights, got %d", count)
+	}
+	return fmt.Errorf("expected <p><strong>headline</strong></p><p>summary</p><ul><li>…</li></ul> on a single line")
+}
+
+func closeTag(out *strings.Builder, stack []string, name string) []string { // Schließt name inkl. aller darin offenen Tags.
+	index := -1
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == name {
+			index = i
+			break
+		}
+	}
+	if index == -1 { // Nicht offen: schließendes Tag verwerfen.
+		return stack
+	}
+	for i := len(stack) - 1; i >= index; i-- {
+		out.WriteString("</" + stack[i] + ">")
+	}
+	return stack[:index]
+}
+
+func safeHref(attrs string) (string, bool) { // Liefert den href-Wert, wenn er ein erlaubtes Scheme hat.
+	match := hrefPattern.FindStringSubmatch(attrs)
+	if match == nil {
+		return "", false
+	}
+	value := strings.TrimSpace(html.UnescapeString(match[1] + match[2] + match[3]))
+	parsed, err := url.Parse(value)
+	if err != nil || !allowedSchemes[strings.ToLower(parsed.Scheme)] {
+		return "", false // Blockt javascript:, data:, relative Pfade usw.
+	}
+	return value, true
+}
+
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;") // Nur was in Text-Knoten nötig ist (Quotes bleiben lesbar).
+
+func escapeText(text string) string { // Normalisiert Text: vorhandene Entities auflösen, dann einheitlich escapen.
+	return textEscaper.Replace(html.UnescapeString(text))
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "allowed tags stay", input: "<p><strong>Hi</strong> <em>there</em></p>", want: "<p><strong>Hi</strong> <em>there</em></p>"},
+		{name: "code fence", input: "```html\n<p>x</p>\n```", want: "<p>x</p>"},
+		{name: "script dropped with content", input: "<p>a<script>alert(1)</script>b</p>", want: "<p>ab</p>"},
+		{name: "unknown tag keeps text", input: "<div><p>Hello <span>world</span></p></div>", want: "<p>Hello world</p>"},
+		{name: "attributes stripped", input: `<p class="x" onclick="evil()">a</p>`, want: "<p>a</p>"},
+		{name: "safe link", input: `<a href='https://wordpress.org/?a=1&amp;b=2' target="_blank">wp</a>`, want: `<a href="https://wordpress.org/?a=1&amp;b=2">wp</a>`},
+		{name: "javascript link", input: `<a href="javascript:alert(1)">x</a>`, want: "x"},
+		{name: "relative link", input: `<a href="/news/">x</a>`, want: "x"},
+		{name: "unclosed tags closed", input: "<p><strong>open", want: "<p><strong>open</strong></p>"},
+		{name: "li without ul", input: "<li>a</li>", want: "a"},
+		{name: "li closes previous li", input: "<ul><li>a<li>b</ul>", want: "<ul><li>a</li><li>b</li></ul>"},
+		{name: "whitespace between blocks", input: "<p>a</p>\n  <ul>\n<li>b</li>\n</ul>", want: "<p>a</p><ul><li>b</li></ul>"},
+		{name: "text escaped", input: "a < b & c", want: "a &lt; b &amp; c"},
+		{name: "comment removed", input: "<p>a<!-- hidden --></p>", want: "<p>a</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.input); got != tt.want {
+				t.Errorf("SanitizeHTML(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateReleaseHTML(t *testing.T) {
+	const (
+		head = "<p><strong>WordPress 6.5 is here!</strong></p><p>A major release.</p>"
+		li   = "<li><strong>Fonts:</strong> Manage fonts.</li>"
+	)
+	tests := []struct {
+		name    string
+		content string
+		wantErr string // Leer => gültig.
+	}{
+		{name: "two highlights", content: head + "<ul>" + li + li + "</ul>"},
+		{name: "four highlights", content: head + "<ul>" + li + li + li + li + "</ul>"},
+		{name: "linked highlight", content: head + `<ul>` + li + `<li>See the <a href="https://wordpress.org/news/">announcement</a>.</li></ul>`},
+		{name: "linked summary", content: `<p><strong>WordPress 6.5</strong></p><p>Read <a href="https://wordpress.org/">more</a>.</p><ul>` + li + li + "</ul>"},
+		{name: "surrounding whitespace", content: "\n " + head + "<ul>" + li + li + "</ul>\n"},
+		{name: "empty", content: "  ", wantErr: "empty response"},
+		{name: "one highlight", content: head + "<ul>" + li + "</ul>", wantErr: "got 1"},
+		{name: "five highlights", content: head + "<ul>" + li + li + li + li + li + "</ul>", wantErr: "got 5"},
+		{name: "missing headline", content: "<p>A major release.</p><ul>" + li + li + "</ul>", wantErr: "expected <p><strong>headline"},
+		{name: "nested list", content: head + "<ul><li><ul><li>a</li></ul></li>" + li + "</ul>", wantErr: "expected <p><strong>headline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReleaseHTML(tt.content)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("ValidateReleaseHTML: unexpected error %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("ValidateReleaseHTML: want error containing %q, got nil", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("ValidateReleaseHTML: error %q does not contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSanitizedReleaseStaysValid(t *testing.T) { // Was SanitizeHTML durchlässt, darf der Validator nicht ablehnen.
+	rendered := "```html\n<p><strong>WordPress 6.5 is here!</strong></p>\n<p>See <a href=\"https://wordpress.org/news/\" rel=\"nofollow\">the post</a>.</p>\n<ul><li><em>Fonts</em> for everyone</li><li>More &amp; better</li></ul>\n```"
+	if err := ValidateReleaseHTML(SanitizeHTML(rendered)); err != nil {
+		t.Fatalf("ValidateReleaseHTML(SanitizeHTML(...)) = %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package feed // Paket "feed": hier liegt die Registry der eingebauten Parser-Arten (kinds).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"context"       // Fetcher/Parser sind abbrechbar.
+	"errors"        // ErrNotModified.
+	"fmt"           // Fehlertexte beim Datums-Parsing.
+	"sort"          // Sortiert die Kind-Namen für stabile Fehlermeldungen.
+	"strings"       // Trimmen von Konfigurationswerten.
+	"text/template" // Prompt-Templates der Quelle.
+	"time"          // Since-Filter und PubDate-Parsing.
+)
+
+const defaultMaxItems = 5 // Obergrenze pro Lauf, wenn in der Konfiguration nichts gesetzt ist.
+
+// Fetcher lädt die Rohdaten einer URL; source ist ein Label für Fehlermeldungen/Logging, ctx bricht den Abruf ab.
+type Fetcher func(ctx context.Context, url, source string) ([]byte, error)
+
+// Source beschreibt eine konfigurierte Quelle so, wie ein Parser sie braucht.
+type Source struct {
+	Name     string                 // Provider-Name aus der Konfiguration (z.B. "wordpress-releases").
+	URL      string                 // Feed-URL; leer => Default-URL des Parsers.
+	Prompt   *template.Template     // Prompt-Template aus der Konfiguration; nil => Default-Prompt des Parsers.
+	Prompts  Prompts                // Alle geladenen Prompts, um den Default per Name aufzulösen.
+	Locale   string                 // Zielsprache für Prompts ({{.Locale}}).
+	MaxItems int                    // Maximale Anzahl Items pro Lauf; <= 0 => defaultMaxItems.
+	Since    time.Time              // Zeitpunkt des zuletzt gesehenen Entries dieser Quelle; Zero => alles ist neu.
+	Slugs    []string               // Plugin-Slugs (Parser-Art "wordpress-plugins").
+	Seen     func(guid string) bool // Meldet, ob ein Item mit dieser GUID schon als Entry existiert; nil => nichts gesehen.
+}
+
+// Parser holt eine Quelle ab und liefert ihre Items im internen Format; bei Abbruch von ctx liefert er ctx.Err().
+type Parser func(ctx context.Context, src Source, fetch Fetcher) ([]Item, error)
+
+const ( // Namen der eingebauten Parser-Arten, so wie sie in data/providers.json stehen.
+	KindReleases     = "wordpress-releases" // WordPress.org News, Kategorie Releases.
+	KindWordPressTV  = "wordpress-tv"       // WordPress.tv Videos.
+	KindWordPressCom = "wordpress-com"      // WordPress.com Blog.
+	KindFeed         = "rss"                // Beliebiger RSS-, RDF- oder Atom-Feed (URL Pflicht).
+	KindWPRest       = "wp-rest"            // WordPress REST API (/wp-json/wp/v2/posts; URL Pflicht).
+	KindPlugins      = "wordpress-plugins"  // Plugin-Updates aus dem Plugin-Verzeichnis (api.wordpress.org; Slugs Pflicht).
+)
+
+var parsers = map[string]Parser{ // Registry: kind → Parser-Funktion.
+	KindReleases:     LatestReleases,
+	KindWordPressTV:  LatestWordPressTV,
+	KindWordPressCom: LatestWordPressComBlog,
+	KindFeed:         LatestFeed,
+	KindWPRest:       LatestWordPressREST,
+	KindPlugins:      LatestPluginUpdates,
+}
+
+var needsURL = map[string]bool{ // Parser-Arten ohne Default-URL.
+	KindFeed:   true,
+	KindWPRest: true,
+}
+
+var feedKinds = map[string]string{ // Parser-Arten, die einen RSS/Atom-Feed lesen → Default-URL (leer => URL Pflicht).
+	KindReleases:     releasesFeedURL,
+	KindWordPressTV:  wordpressTVFeedURL,
+	KindWordPressCom: wordpressComFeedURL,
+	KindFeed:         "",
+}
+
+var needsSlugs = map[string]bool{ // Parser-Arten, die eine Slug-Liste brauchen.
+	KindPlugins: true,
+}
+
+// LookupParser liefert den Parser für eine Parser-Art aus der Konfiguration.
+func LookupParser(kind string) (Parser, bool) {
+	parser, ok := parsers[strings.TrimSpace(kind)] // Kind normalisieren, damit " rss" nicht scheitert.
+	return parser, ok
+}
+
+// NeedsURL meldet, ob eine Parser-Art zwingend eine URL in der Konfiguration braucht.
+func NeedsURL(kind string) bool {
+	return needsURL[strings.TrimSpace(kind)]
+}
+
+// NeedsSlugs meldet, ob eine Parser-Art zwingend Slugs in der Konfiguration braucht.
+func NeedsSlugs(kind string) bool {
+	return needsSlugs[strings.TrimSpace(kind)]
+}
+
+// FeedURL liefert die Feed-URL einer Quelle: die konfigurierte URL, sonst den Default der Parser-Art.
+// false, wenn die Parser-Art keinen Feed liest (z.B. wp-rest, wordpress-plugins) oder keine URL bekannt ist.
+func FeedURL(kind, url string) (string, bool) {
+	fallback, ok := feedKinds[strings.TrimSpace(kind)]
+	if !ok {
+		return "", false
+	}
+	if url = strings.TrimSpace(url); url != "" {
+		return url, true
+	}
+	return fallback, fallback != ""
+}
+
+// Kinds listet alle bekannten Parser-Arten (sortiert).
+func Kinds() []string {
+	kinds := make([]string, 0, len(parsers))
+	for kind := range parsers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds) // Map-Reihenfolge ist zufällig; für Ausgaben stabil sortieren.
+	return kinds
+}
+
+func (s Source) feedURL(fallback string) string { // URL aus der Konfiguration, sonst Default des Parsers.
+	if url := strings.TrimSpace(s.URL); url != "" {
+		return url
+	}
+	return fallback
+}
+
+func (s Source) label(fallback string) string { // Label für fetch-Fehlermeldungen: Provider-Name bevorzugt.
+	if name := strings.TrimSpace(s.Name); name != "" {
+		return name
+	}
+	return fallback
+}
+
+func (s Source) renderPrompt(fallback string, data PromptData) (string, error) { // Rendert den Prompt der Quelle, sonst den Default per Name.
+	tmpl := s.Prompt
+	if tmpl == nil {
+		tmpl = s.Prompts[fallback]
+	}
+	if tmpl == nil { // Source ohne LoadPrompts gebaut: eingebautes Pattern direkt parsen.
+		parsed, err := builtinPrompt(fallback)
+		if err != nil {
+			return "", err
+		}
+		tmpl = parsed
+	}
+	if data.Locale == "" {
+		data.Locale = s.Locale
+	}
+	return renderPrompt(tmpl, data)
+}
+
+func (s Source) limit() int { // Maximale Anzahl Items pro Lauf.
+	if s.MaxItems <= 0 {
+		return defaultMaxItems
+	}
+	return s.MaxItems
+}
+
+func (s Source) fresh(items []Item) []Item { // Neue Items in Feed-Reihenfolge, höchstens limit() Stück.
+	result := make([]Item, 0, s.limit())
+	for _, item := range items {
+		if len(result) >= s.limit() {
+			break
+		}
+		if s.isNew(item.PubDate) { // Schon gesehen: überspringen statt abbrechen, falls der Feed nicht streng sortiert ist.
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func (s Source) seen(guid string) bool { // Prüft, ob ein Item mit dieser GUID schon gespeichert ist.
+	return s.Seen != nil && s.Seen(guid)
+}
+
+func (s Source) isNew(pubDate string) bool { // Prüft, ob ein Item neuer als der zuletzt gesehene Entry ist.
+	if s.Since.IsZero() { // Noch nichts gesehen (erster Lauf / neue Quelle)…
+		return true // …dann ist alles neu (Obergrenze greift über limit()).
+	}
+	published, err := ParsePubDate(pubDate)
+	if err != nil { // Unlesbares Datum: lieber durchlassen, die ID-Deduplizierung fängt Wiederholungen ab.
+		return true
+	}
+	return published.After(s.Since)
+}
+
+var pubDateLayouts = []string{ // Reihenfolge: die häufigsten Formate zuerst.
+	time.RFC1123Z,                    // RSS pubDate mit Offset.
+	time.RFC1123,                     // RSS pubDate mit Zonenkürzel.
+	time.RFC3339Nano,                 // Atom published/updated, dc:date (deckt RFC3339 ab).
+	"Mon, 2 Jan 2006 15:04:05 -0700", // Einstelliger Tag.
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700", // Ohne Wochentag.
+	"2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700", // Ohne Sekunden (RFC822).
+	"Mon, 2 Jan 2006 15:04 MST",
+	"2006-01-02 3:04pm MST", // api.wordpress.org last_updated.
+	"2006-01-02T15:04:05",   // ISO ohne Zone (als UTC).
+	"2006-01-02",            // Nur Datum (dc:date).
+}
+
+// ParsePubDate parst Datums-Strings aus RSS/RDF/Atom-Feeds (RFC1123/RFC822-Varianten und RFC3339).
+func ParsePubDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value) // Whitespace entfernen.
+	if value == "" {                 // Wenn leer…
+		return time.Time{}, fmt.Errorf("empty pubDate") // …Fehler, damit Caller fallbacken kann.
+	}
+	for _, layout := range pubDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format %q", value)
+}
+
+// ErrNotModified meldet ein Fetcher, wenn die Quelle seit dem letzten Abruf unverändert ist (HTTP 304).
+var ErrNotModified = errors.New("not modified")
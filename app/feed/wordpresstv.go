@@ -1,91 +1,67 @@
 package feed // Definiert das Paket "feed"; hier liegt die WordPress-TV-Feed-Logik.
 
 import ( // Import-Block: Abhängigkeiten dieser Datei.
-	"encoding/xml" // RSS-XML wird damit in Go-Structs unmarshalled.
-	"fmt"          // Wird für HTML-String-Zusammenbau (Sprintf) genutzt.
-	"regexp"       // Wird genutzt, um HTML-Teile (iframe/a) per Regex zu finden/ersetzen.
-	"strings"      // Trimmen, Suchen, Ersetzen; robustes String-Handling.
+	"context" // Abbruch des HTTP-Abrufs.
+	"fmt"     // Wird für HTML-String-Zusammenbau (Sprintf) genutzt.
+	"regexp"  // Wird genutzt, um HTML-Teile (iframe/a) per Regex zu finden/ersetzen.
+	"strings" // Trimmen, Suchen, Ersetzen; robustes String-Handling.
 )
 
 const wordpressTVFeedURL = "https://wordpress.tv/feed/" // URL des WordPress.tv RSS-Feeds (Quelle für neueste Videos).
 
 var ( // Globale, vorcompilierte Regexe: einmalig bauen (effizient) und mehrfach verwenden.
-	iframePattern       = regexp.MustCompile(`(?is)<iframe\b[^>]*>.*?</iframe>`)
+	iframePattern = regexp.MustCompile(`(?is)<iframe\b[^>]*>.*?</iframe>`)
 	// Findet den ersten kompletten <iframe ...>...</iframe>-Block (case-insensitive + dot matches newline).
 
-	iframeWidthPattern  = regexp.MustCompile(`(?i)\swidth\s*=\s*(?:"[^"]*"|'[^']*'|[^'"\s>]+)`)
+	iframeWidthPattern = regexp.MustCompile(`(?i)\swidth\s*=\s*(?:"[^"]*"|'[^']*'|[^'"\s>]+)`)
 	// Findet width=... im iframe-Open-Tag, egal ob in "..." '...' oder unquoted (case-insensitive).
 
 	iframeHeightPattern = regexp.MustCompile(`(?i)\sheight\s*=\s*(?:"[^"]*"|'[^']*'|[^'"\s>]+)`)
 	// Findet height=... im iframe-Open-Tag; gleiche Logik wie width.
 
-	iframeAllowPattern  = regexp.MustCompile(`(?i)\sallow\s*=\s*(?:"[^"]*"|'[^']*'|[^'"\s>]+)`)
+	iframeAllowPattern = regexp.MustCompile(`(?i)\sallow\s*=\s*(?:"[^"]*"|'[^']*'|[^'"\s>]+)`)
 	// Findet allow=... im iframe-Open-Tag; wichtig, um gewünschte Permissions zu setzen.
 
-	anchorBlockPattern  = regexp.MustCompile(`(?is)<a\b[^>]*>.*?</a>`)
+	anchorBlockPattern = regexp.MustCompile(`(?is)<a\b[^>]*>.*?</a>`)
 	// Findet komplette <a ...>...</a>-Blöcke (inkl. Inhalt) und kann sie komplett entfernen.
 
-	anchorTagPattern    = regexp.MustCompile(`(?is)</?a\b[^>]*>`)
+	anchorTagPattern = regexp.MustCompile(`(?is)</?a\b[^>]*>`)
 	// Findet nur die <a ...> und </a> Tags (ohne Inhalt), um "nur Tags" zu strippen.
 )
 
-type wordPressTVFeed struct { // Root-Struktur für das RSS-XML (minimaler Ausschnitt).
-	Channel wordPressTVChannel `xml:"channel"` // Mappt <channel>...</channel> aus dem RSS.
-}
-
-type wordPressTVChannel struct { // Channel enthält die Items.
-	Items []wordPressTVItem `xml:"item"` // Mappt alle <item>-Elemente in einen Slice.
-}
-
-type wordPressTVItem struct { // Struktur eines einzelnen WordPress.tv RSS-Items.
-	Title          string   `xml:"title"`       // Titel des Videos/Posts.
-	Link           string   `xml:"link"`        // Link zur WordPress.tv Seite.
-	PubDate        string   `xml:"pubDate"`     // Veröffentlichungsdatum als RSS-String.
-	Description    string   `xml:"description"` // Kurzbeschreibung (oft HTML, oft mit Links).
-	ContentEncoded string   `xml:"encoded"`     // Vollcontent (häufig inkl. iframe embed).
-	Categories     []string `xml:"category"`    // Kategorien/Tags.
-}
-
-func LatestWordPressTV(fetch func(url, source string) ([]byte, error)) (Item, error) {
-	// Exportierte Funktion: holt den neuesten WordPress.tv Eintrag und mappt ihn ins interne Item-Format.
+func LatestWordPressTV(ctx context.Context, src Source, fetch Fetcher) ([]Item, error) {
+	// Exportierte Funktion (Parser-Art "wordpress-tv"): holt die neuesten WordPress.tv Einträge im internen Item-Format.
 	// fetch wird injiziert, damit HTTP-Details zentral bleiben und Tests leicht sind.
 
-	body, err := fetch(wordpressTVFeedURL, "wordpress tv")
-	// Holt den RSS-Feed (Bytes). "wordpress tv" dient als Source-Label für Fehlertexte/Logging.
+	body, err := fetch(ctx, src.feedURL(wordpressTVFeedURL), src.label("wordpress tv"))
+	// Holt den RSS-Feed (Bytes). URL und Label kommen aus der Provider-Konfiguration (mit Defaults).
 
 	if err != nil {
 		// Wenn Fetch fehlschlägt (Netzwerk, Timeout, non-2xx)…
-		return Item{}, err
-		// …gibt leeres Item + Fehler zurück.
+		return nil, err
+		// …gibt keine Items + Fehler zurück.
 	}
 
-	var feed wordPressTVFeed
-	// Zielvariable für das XML-Unmarshal.
+	parsed, err := ParseFeed(body)
+	// Generischer Feed-Parser (RSS/RDF/Atom) inkl. content:encoded und media:*.
 
-	if err := xml.Unmarshal(body, &feed); err != nil {
-		// XML parsen; Fehler bei invalidem XML oder abweichender Struktur.
-		return Item{}, err
+	if err != nil {
+		// Ungültiges XML oder unbekanntes Format.
+		return nil, err
 	}
 
-	if len(feed.Channel.Items) == 0 {
-		// Feed hat keine Items → nichts zu liefern.
-		return Item{}, nil
-	}
+	items := make([]Item, 0, src.limit())
+	for _, item := range src.fresh(parsed) {
+		// Nur neue Items bis zur Obergrenze; der Feed muss dafür nicht streng sortiert sein.
 
-	item := feed.Channel.Items[0]
-	// Nimmt das erste Item als "latest" (Annahme: Feed ist absteigend sortiert, typisch für RSS).
+		item.Content = buildWordPressTVContent(item.Title, item.Summary, item.Content)
+		// Baut den HTML-Content: Header (Titel/Beschreibung) + normalisiertes iframe + Entfernen von <a>-Tags.
 
-	content := buildWordPressTVContent(item.Title, item.Description, item.ContentEncoded)
-	// Baut den HTML-Content: Header (Titel/Beschreibung) + normalisiertes iframe + Entfernen von <a>-Tags.
+		items = append(items, item)
+	}
 
-	return Item{
-		Title:      item.Title,      // Titel übernehmen.
-		Link:       item.Link,       // Link übernehmen.
-		PubDate:    item.PubDate,    // PubDate übernehmen (wird später normalisiert).
-		Content:    content,         // Finaler HTML-Content.
-		Categories: item.Categories, // Kategorien übernehmen.
-	}, nil
-	// Erfolgreich: standardisiertes Item zurück.
+	return items, nil
+	// Erfolgreich: standardisierte Items zurück (leer, wenn der Feed leer ist).
 }
 
 func buildWordPressTVContent(title, description, encoded string) string {
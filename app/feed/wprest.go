@@ -0,0 +1,131 @@
+package feed // Paket "feed": Parser-Art "wp-rest" – Beiträge über die WordPress REST API (/wp-json/wp/v2/posts).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"context"       // Abbruch von HTTP- und KI-Calls.
+	"encoding/json" // REST-Antwort parsen.
+	"fmt"           // Fehlertexte.
+	"html"          // title.rendered enthält HTML-Entities (&#8217; …).
+	"net/url"       // Endpoint + Query-Parameter bauen.
+	"strconv"       // per_page.
+	"strings"       // Trimmen, Pfade.
+	"time"          // after= aus Source.Since.
+)
+
+const wpRestPostsPath = "wp-json/wp/v2/posts" // Standard-Endpoint relativ zur Site-URL.
+
+const wpRestAfterWindow = 14 * time.Hour // after= vergleicht mit dem lokalen post_date der Site; deckt jeden UTC-Offset ab.
+
+type wpPost struct { // Ausschnitt eines Beitrags aus /wp/v2/posts?_embed.
+	Link     string     `json:"link"`
+	DateGMT  string     `json:"date_gmt"` // "2025-04-30T15:00:00" (UTC, ohne Zone).
+	Title    wpRendered `json:"title"`
+	Content  wpRendered `json:"content"`
+	Excerpt  wpRendered `json:"excerpt"`
+	Embedded wpEmbedded `json:"_embedded"`
+}
+
+type wpRendered struct {
+	Rendered string `json:"rendered"`
+}
+
+type wpEmbedded struct { // Per _embed mitgelieferte Objekte: spart einen Request pro Kategorie/Tag/Autor.
+	Author        []wpAuthor `json:"author"`
+	Terms         [][]wpTerm `json:"wp:term"` // Je Taxonomie eine Liste (category, post_tag, …).
+	FeaturedMedia []wpMedia  `json:"wp:featuredmedia"`
+}
+
+type wpAuthor struct {
+	Name string `json:"name"`
+}
+
+type wpTerm struct {
+	Name     string `json:"name"`
+	Taxonomy string `json:"taxonomy"`
+}
+
+type wpMedia struct {
+	SourceURL string `json:"source_url"`
+	MimeType  string `json:"mime_type"`
+}
+
+// LatestWordPressREST ist die Parser-Art "wp-rest": fragt neue Beiträge einer WordPress-Site über die REST API ab.
+// Die URL ist die Site-URL (z.B. https://make.wordpress.org/core/) oder direkt ein /wp-json/…-Endpoint.
+func LatestWordPressREST(ctx context.Context, src Source, fetch Fetcher) ([]Item, error) {
+	endpoint, err := wpRestEndpoint(src.URL, src.Since, src.limit())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", src.label(KindWPRest), err)
+	}
+
+	body, err := fetch(ctx, endpoint, src.label(src.URL))
+	if err != nil {
+		return nil, err
+	}
+	var posts []wpPost
+	if err := json.Unmarshal(body, &posts); err != nil {
+		return nil, fmt.Errorf("%s: %w", src.label(src.URL), err)
+	}
+
+	parsed := make([]Item, 0, len(posts))
+	for _, post := range posts {
+		parsed = append(parsed, post.item())
+	}
+
+	items := make([]Item, 0, src.limit())
+	for _, item := range src.fresh(parsed) { // after= filtert grob mit Sicherheitsfenster; isNew vergleicht exakt über date_gmt.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		item.Content = buildFeedContent(ctx, src, item)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (p wpPost) item() Item { // REST-Beitrag → Item (Content/Summary noch unbereinigt).
+	item := Item{
+		Title:   strings.TrimSpace(html.UnescapeString(stripTags(p.Title.Rendered))),
+		Link:    strings.TrimSpace(p.Link),
+		PubDate: p.DateGMT,
+		Content: p.Content.Rendered,
+		Summary: p.Excerpt.Rendered,
+	}
+	for _, terms := range p.Embedded.Terms {
+		for _, term := range terms {
+			if name := strings.TrimSpace(html.UnescapeString(term.Name)); name != "" {
+				item.Categories = append(item.Categories, name)
+			}
+		}
+	}
+	if len(p.Embedded.Author) > 0 {
+		item.Author = strings.TrimSpace(p.Embedded.Author[0].Name)
+	}
+	for _, media := range p.Embedded.FeaturedMedia { // Beitragsbild als Enclosure.
+		item.Enclosures = appendEnclosure(item.Enclosures, media.SourceURL, media.MimeType, "")
+	}
+	return item
+}
+
+func wpRestEndpoint(site string, since time.Time, limit int) (string, error) { // Baut /wp/v2/posts?_embed&after=…&per_page=….
+	site = strings.TrimSpace(site)
+	if site == "" {
+		return "", fmt.Errorf("kind %q needs a url", KindWPRest)
+	}
+	endpoint, err := url.Parse(site)
+	if err != nil || endpoint.Host == "" {
+		return "", fmt.Errorf("invalid url %q", site)
+	}
+	if !strings.Contains(endpoint.Path, "/wp-json/") { // Site-URL: Standard-Endpoint anhängen.
+		endpoint.Path = strings.TrimRight(endpoint.Path, "/") + "/" + wpRestPostsPath
+	}
+
+	query := endpoint.Query() // Vorhandene Filter (z.B. categories=5) bleiben erhalten.
+	query.Set("_embed", "1")
+	query.Set("per_page", strconv.Itoa(min(limit, 100))) // Die API erlaubt maximal 100.
+	query.Set("orderby", "date")
+	query.Set("order", "desc")
+	if !since.IsZero() {
+		query.Set("after", since.UTC().Add(-wpRestAfterWindow).Format("2006-01-02T15:04:05")) // Ohne Zone: WordPress liest es als Site-Zeit.
+	}
+	endpoint.RawQuery = query.Encode()
+	return endpoint.String(), nil
+}
@@ -0,0 +1,135 @@
+package feed
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWPRestEndpoint(t *testing.T) {
+	since := time.Date(2026, 4, 10, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	tests := []struct {
+		name    string
+		site    string
+		since   time.Time
+		limit   int
+		path    string
+		query   url.Values
+		wantErr string
+	}{
+		{
+			name:  "site url",
+			site:  "https://make.wordpress.org/core/",
+			limit: 10,
+			path:  "/core/wp-json/wp/v2/posts",
+			query: url.Values{"_embed": {"1"}, "per_page": {"10"}, "orderby": {"date"}, "order": {"desc"}},
+		},
+		{
+			name:  "site without trailing slash",
+			site:  "https://example.org",
+			limit: 5,
+			path:  "/wp-json/wp/v2/posts",
+			query: url.Values{"_embed": {"1"}, "per_page": {"5"}, "orderby": {"date"}, "order": {"desc"}},
+		},
+		{
+			name:  "explicit endpoint keeps filters, since shifted by the window",
+			site:  "https://example.org/wp-json/wp/v2/posts?categories=5",
+			since: since,
+			limit: 250,
+			path:  "/wp-json/wp/v2/posts",
+			query: url.Values{
+				"categories": {"5"},
+				"_embed":     {"1"},
+				"per_page":   {"100"}, // API-Maximum.
+				"orderby":    {"date"},
+				"order":      {"desc"},
+				"after":      {"2026-04-09T20:00:00"}, // 10:00 UTC minus 14h, ohne Zone.
+			},
+		},
+		{name: "empty", site: " ", wantErr: "needs a url"},
+		{name: "relative", site: "/wp-json/wp/v2/posts", wantErr: "invalid url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wpRestEndpoint(tt.site, tt.since, tt.limit)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("wpRestEndpoint(%q) error = %v, want %q", tt.site, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := url.Parse(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.Path != tt.path {
+				t.Errorf("path = %q, want %q", parsed.Path, tt.path)
+			}
+			if !reflect.DeepEqual(parsed.Query(), tt.query) {
+				t.Errorf("query = %v, want %v", parsed.Query(), tt.query)
+			}
+		})
+	}
+}
+
+const wpRestResponse = `[
+{
+  "link": "https://example.org/new/",
+  "date": "2026-04-10T07:30:00",
+  "date_gmt": "2026-04-10T12:30:00",
+  "title": {"rendered": "Hello &#8217;World&#8217; <em>now</em>"},
+  "content": {"rendered": "<p>Full</p>"},
+  "excerpt": {"rendered": "<p>Short</p>"},
+  "_embedded": {
+    "author": [{"name": " Jane "}],
+    "wp:term": [[{"name": "News &amp; Events", "taxonomy": "category"}], [{"name": "core", "taxonomy": "post_tag"}, {"name": " "}]],
+    "wp:featuredmedia": [{"source_url": "https://example.org/cover.jpg", "mime_type": "image/jpeg"}]
+  }
+},
+{
+  "link": "https://example.org/old/",
+  "date": "2026-04-10T06:00:00",
+  "date_gmt": "2026-04-10T11:00:00",
+  "title": {"rendered": "Inside the after= window"},
+  "content": {"rendered": "<p>Old</p>"}
+}
+]`
+
+func TestLatestWordPressREST(t *testing.T) {
+	var requested string
+	fetch := func(_ context.Context, endpoint, _ string) ([]byte, error) {
+		requested = endpoint
+		return []byte(wpRestResponse), nil
+	}
+	src := Source{
+		URL:   "https://example.org/",
+		Since: time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC), // Site liegt bei UTC-5: post_date hinkt date_gmt hinterher.
+	}
+
+	items, err := LatestWordPressREST(context.Background(), src, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(requested, "after=2026-04-09T22%3A00%3A00") {
+		t.Errorf("endpoint = %q, want after= with safety window", requested)
+	}
+	want := []Item{{
+		Title:      "Hello ’World’ now",
+		Link:       "https://example.org/new/",
+		PubDate:    "2026-04-10T12:30:00",
+		Author:     "Jane",
+		Categories: []string{"News & Events", "core"},
+		Summary:    "<p>Short</p>",
+		Content:    "<p>Full</p>",
+		Enclosures: []Enclosure{{URL: "https://example.org/cover.jpg", Type: "image/jpeg"}},
+	}}
+	if !reflect.DeepEqual(items, want) { // Der ältere Beitrag aus dem Fenster wird über date_gmt verworfen.
+		t.Errorf("items:\n got %+v\nwant %+v", items, want)
+	}
+}
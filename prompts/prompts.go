@@ -0,0 +1,8 @@
+package prompts // Paket "prompts": bettet die mitgelieferten Prompt-Templates ein (einzige Quelle der eingebauten Prompts).
+
+import "embed" // *.tmpl ins Binary übernehmen.
+
+// Files enthält prompts/*.tmpl zum Build-Zeitpunkt; feed.LoadPrompts nutzt sie, wenn zur Laufzeit eine Datei fehlt.
+//
+//go:embed *.tmpl
+var Files embed.FS